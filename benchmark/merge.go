@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"gpandas/dataframe"
+	"math/rand"
+	"runtime"
+	"time"
+)
+
+// mergeengines joins a 2,000,000-row DataFrame with a 1,000,000-row one on an int key
+// with the sequential HashEngine and the partitioned ParallelHashEngine, reporting the
+// time each merge type takes and the speedup.
+func mergeengines() {
+	const leftRows, rightRows = 2000000, 1000000
+
+	rng := rand.New(rand.NewSource(1))
+	frame := func(rows int, value string) *dataframe.DataFrame {
+		keys, values := make([]int64, rows), make([]float64, rows)
+		for i := range keys {
+			keys[i], values[i] = rng.Int63n(leftRows), rng.Float64()
+		}
+		return &dataframe.DataFrame{
+			Columns: []string{"key", value},
+			Data:    []dataframe.Series{dataframe.NewIntCol(keys), dataframe.NewFloatCol(values)},
+		}
+	}
+	left, right := frame(leftRows, "price"), frame(rightRows, "cost")
+
+	timeMerge := func(how dataframe.MergeHow, engine dataframe.MergeEngine) (time.Duration, int) {
+		start := time.Now()
+		result, err := left.MergeWith(right, dataframe.MergeOptions{How: how, On: []string{"key"}, Engine: engine})
+		if err != nil {
+			fmt.Printf("Error merging: %v\n", err)
+			return 0, 0
+		}
+		return time.Since(start), result.Data[0].Len()
+	}
+
+	fmt.Printf("%d x %d rows on %d CPUs\n", leftRows, rightRows, runtime.NumCPU())
+	for _, how := range []dataframe.MergeHow{dataframe.InnerMerge, dataframe.LeftMerge, dataframe.FullMerge, dataframe.SemiMerge} {
+		hash, rows := timeMerge(how, dataframe.HashEngine)
+		parallel, _ := timeMerge(how, dataframe.ParallelHashEngine)
+		fmt.Printf("%-5s %8d rows: hash %f, parallel_hash %f (%.1fx)\n",
+			how, rows, hash.Seconds(), parallel.Seconds(), hash.Seconds()/parallel.Seconds())
+	}
+}
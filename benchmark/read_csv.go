@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"gpandas"
+	"io"
 	"time"
 )
 
@@ -17,3 +18,30 @@ func readcsv() {
 	elapsed := time.Since(start)
 	fmt.Printf("%f\n", elapsed.Seconds())
 }
+
+// readcsvchunks reads the same file in chunks of 100,000 rows, keeping at most one chunk
+// in memory at a time.
+func readcsvchunks() {
+	start := time.Now()
+	gp := gpandas.GoPandas{}
+	chunks, err := gp.ReadCSVChunks("./customers-2000000.csv", 100000)
+	if err != nil {
+		fmt.Printf("Error reading CSV: %v\n", err)
+		return
+	}
+	defer chunks.Close()
+	rows := 0
+	for {
+		df, err := chunks.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			fmt.Printf("Error reading CSV chunk: %v\n", err)
+			return
+		}
+		rows += df.Data[0].Len()
+	}
+	elapsed := time.Since(start)
+	fmt.Printf("%d rows in %f\n", rows, elapsed.Seconds())
+}
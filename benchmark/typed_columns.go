@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"gpandas/dataframe"
+	"runtime"
+	"time"
+)
+
+// heapAlloc returns the bytes currently allocated on the heap after a full GC.
+func heapAlloc() uint64 {
+	var m runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&m)
+	return m.HeapAlloc
+}
+
+// typedcolumns compares a boxed []any column against a typed FloatCol holding the
+// same 2,000,000 values, reporting heap usage and the time to sum each column.
+func typedcolumns() {
+	const n = 2000000
+
+	before := heapAlloc()
+	boxed := make([]any, n)
+	for i := range boxed {
+		boxed[i] = float64(i) + 0.5
+	}
+	boxedBytes := heapAlloc() - before
+
+	start := time.Now()
+	boxedSum := 0.0
+	for _, v := range boxed {
+		boxedSum += v.(float64)
+	}
+	boxedElapsed := time.Since(start)
+
+	before = heapAlloc()
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = float64(i) + 0.5
+	}
+	typed := dataframe.NewFloatCol(values)
+	typedBytes := heapAlloc() - before
+
+	start = time.Now()
+	typedSum := 0.0
+	for _, v := range typed.Values() {
+		typedSum += v
+	}
+	typedElapsed := time.Since(start)
+
+	fmt.Printf("[]any:    %d MiB, sum %f in %f\n", boxedBytes>>20, boxedSum, boxedElapsed.Seconds())
+	fmt.Printf("FloatCol: %d MiB, sum %f in %f\n", typedBytes>>20, typedSum, typedElapsed.Seconds())
+	runtime.KeepAlive(boxed)
+}
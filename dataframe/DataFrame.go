@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"gpandas/utils/collection"
-	"os"
 	"sync"
 
 	"github.com/olekukonko/tablewriter"
@@ -13,24 +12,6 @@ import (
 
 type GoPandas struct{}
 
-// FloatColumn represents a column slice of float64 values.
-type FloatCol []float64
-
-// StringColumn represents a column slice of string values.
-type StringCol []string
-
-// IntColumn represents a column slice of int64 values.
-type IntCol []int64
-
-// BoolColumn represents a column slice of bool values.
-type BoolCol []bool
-
-// Column represents a column slice of any type.
-type Column []any
-
-// TypeColumn represents a column slice of a comparable type T.
-type TypeColumn[T comparable] []T
-
 func GetMapKeys[K comparable, V any](input_map map[K]V) (collection.Set[K], error) {
 	keys, err := collection.NewSet[K]()
 	if err != nil {
@@ -42,10 +23,80 @@ func GetMapKeys[K comparable, V any](input_map map[K]V) (collection.Set[K], erro
 	return keys, nil
 }
 
+// DataFrame is a two-dimensional, labeled table of data.
+//
+// Data is stored column-major: Data[c] is the Series holding every value of the
+// column named Columns[c], and Data[c].At(r) is the value at row r of that column.
+// Every loader, printer, exporter and merge routine in gpandas reads and writes this
+// layout, so all entries of Data must have the same length.
+//
+// Index holds the row labels. It is nil for the default RangeIndex (0, 1, 2, ...); when
+// set, its length must match the length of the columns. See RowIndex and SetIndex.
+//
+// Example:
+//
+//	df := &DataFrame{
+//	    Columns: []string{"A", "B"},
+//	    Data: []Series{
+//	        NewIntCol([]int64{1, 2, 3}),
+//	        NewIntCol([]int64{4, 5, 6}),
+//	    },
+//	}
+//	// A | B
+//	// 1 | 4
+//	// 2 | 5
+//	// 3 | 6
 type DataFrame struct {
 	sync.Mutex
 	Columns []string
-	Data    [][]any
+	Data    []Series
+	Index   Index
+}
+
+// columnPositions returns the position in df.Columns of each name in cols, in the order
+// given. Membership is checked with collection.Set, as in Rename, and an error names the
+// first column that is not present in the DataFrame.
+func (df *DataFrame) columnPositions(cols []string) ([]int, error) {
+	wanted, err := collection.ToSet(cols)
+	if err != nil {
+		return nil, err
+	}
+	available, err := collection.ToSet(df.Columns)
+	if err != nil {
+		return nil, err
+	}
+	missing, err := wanted.Difference(available)
+	if err != nil {
+		return nil, err
+	}
+	for _, col := range cols {
+		if missing.Has(col) {
+			return nil, errors.New("the column '" + col + "' is not present in DataFrame")
+		}
+	}
+
+	positions := make([]int, len(cols))
+	for i, col := range cols {
+		for j, name := range df.Columns {
+			if name == col {
+				positions[i] = j
+				break
+			}
+		}
+	}
+	return positions, nil
+}
+
+// rowCount returns the number of rows stored in the DataFrame.
+// A DataFrame without columns has as many rows as its Index, or zero without one.
+func (df *DataFrame) rowCount() int {
+	if len(df.Data) == 0 {
+		if df.Index != nil {
+			return df.Index.Len()
+		}
+		return 0
+	}
+	return df.Data[0].Len()
 }
 
 // Rename changes the names of specified columns in the DataFrame.
@@ -72,7 +123,11 @@ type DataFrame struct {
 //
 //	df := &DataFrame{
 //	    Columns: []string{"A", "B", "C"},
-//	    Data:    [][]any{{1, 2, 3}, {4, 5, 6}},
+//	    Data: []Series{
+//	        NewIntCol([]int64{1, 4}),
+//	        NewIntCol([]int64{2, 5}),
+//	        NewIntCol([]int64{3, 6}),
+//	    },
 //	}
 //
 //	// Rename columns "A" to "X" and "B" to "Y"
@@ -168,12 +223,19 @@ func (df *DataFrame) Rename(columns map[string]string) error {
 //
 //	df := &DataFrame{
 //	    Columns: []string{"A", "B"},
-//	    Data:    [][]any{{1, 2}, {3, 4}},
+//	    Data: []Series{
+//	        NewIntCol([]int64{1, 3}),
+//	        NewIntCol([]int64{2, 4}),
+//	    },
 //	}
 //	fmt.Println(df.String())
 //
 // Note:
-//   - All values are converted to strings using fmt.Sprintf("%v", val)
+//   - All values are converted to strings using fmt.Sprintf("%v", val) on Series.At,
+//     except datetimes which are written as dates or RFC 3339 timestamps
+//   - Null values are shown as NaN in numeric columns and as <null> in all other columns
+//   - A named index (see SetIndex) is shown as the first column; the default RangeIndex
+//     is not shown
 //   - The table is rendered using the github.com/olekukonko/tablewriter package
 func (df *DataFrame) String() string {
 	if df == nil {
@@ -194,22 +256,39 @@ func (df *DataFrame) String() string {
 	table.SetHeaderLine(true)
 	table.SetBorder(true)
 
+	// A named index, set by SetIndex, is shown as the first column
+	var index Index
+	if df.Index != nil && df.Index.Name() != "" {
+		index = df.Index
+	}
+
 	// Set headers using the DataFrame's Columns
-	table.SetHeader(df.Columns)
+	if index != nil {
+		table.SetHeader(append([]string{index.Name()}, df.Columns...))
+	} else {
+		table.SetHeader(df.Columns)
+	}
 
 	// Determine how many rows to display (maximum 10)
-	numRows := len(df.Data)
+	numRows := df.rowCount()
 	displayRows := numRows
 	if numRows > 10 {
 		displayRows = 10
 	}
 
-	// Append only the first displayRows rows to the table
+	// Append only the first displayRows rows to the table, reading each row
+	// across the column-major Data
+	var labels Series
+	if index != nil {
+		labels = index.Labels()
+	}
 	for i := 0; i < displayRows; i++ {
-		row := df.Data[i]
-		stringRow := make([]string, len(row))
-		for j, val := range row {
-			stringRow[j] = fmt.Sprintf("%v", val)
+		stringRow := make([]string, 0, len(df.Data)+1)
+		if labels != nil {
+			stringRow = append(stringRow, displayValue(labels, i))
+		}
+		for _, col := range df.Data {
+			stringRow = append(stringRow, displayValue(col, i))
 		}
 		table.Append(stringRow)
 	}
@@ -226,73 +305,16 @@ func (df *DataFrame) String() string {
 	return buf.String() + shape + "\n"
 }
 
-// ToCSV converts the DataFrame to a CSV string representation or writes it to a file.
-//
-// Parameters:
-//   - filepath: file path to write the CSV to (empty string to return as string)
-//   - separator: optional separator for the CSV (defaults to comma)
-//
-// Returns:
-//   - string: CSV representation of the DataFrame if filepath is empty
-//   - error: nil if successful, otherwise an error describing what went wrong
-//
-// Note: If filepath is provided, the method returns ("", nil) on success
-//
-// Example:
-//
-//	// Get CSV as string with default comma separator
-//	csv, err := df.ToCSV("")
-//
-//	// Get CSV as string with custom separator
-//	csv, err := df.ToCSV("", ";")
-//
-//	// Write to file with default comma separator
-//	_, err := df.ToCSV("path/to/output.csv")
-//
-//	// Write to file with custom separator
-//	_, err := df.ToCSV("path/to/output.csv", ";")
-func (df *DataFrame) ToCSV(filepath string, separator ...string) (string, error) {
-	if df == nil {
-		return "", errors.New("DataFrame is nil")
-	}
-
-	// Default separator is comma
-	sep := ","
-	if len(separator) > 0 {
-		sep = separator[0]
-	}
-
-	var buf bytes.Buffer
-
-	// Write headers
-	for i, col := range df.Columns {
-		if i > 0 {
-			buf.WriteString(sep)
+// displayValue formats the value at row i of col for String, rendering nulls as NaN
+// in numeric columns and as <null> elsewhere.
+func displayValue(col Series, i int) string {
+	if col.IsNull(i) {
+		switch col.DType() {
+		case FloatType, IntType:
+			return "NaN"
+		default:
+			return "<null>"
 		}
-		buf.WriteString(col)
 	}
-	buf.WriteString("\n")
-
-	// Write data rows
-	for _, row := range df.Data {
-		for i, val := range row {
-			if i > 0 {
-				buf.WriteString(sep)
-			}
-			buf.WriteString(fmt.Sprintf("%v", val))
-		}
-		buf.WriteString("\n")
-	}
-
-	// If filepath is provided, write to file and return nil
-	if filepath != "" {
-		err := os.WriteFile(filepath, buf.Bytes(), 0644)
-		if err != nil {
-			return "", fmt.Errorf("failed to write CSV to file: %w", err)
-		}
-		return "", nil
-	}
-
-	// If no filepath, return the CSV string
-	return buf.String(), nil
+	return formatValue(col.At(i))
 }
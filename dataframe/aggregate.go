@@ -0,0 +1,391 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// AggFunc is an aggregation that reduces the values of a column to one value per group.
+// It is passed to GroupedFrame.Agg and GroupedFrame.Aggregate.
+//
+// The built-in aggregations are the package variables AggSum, AggMean, AggMin, AggMax,
+// AggCount, AggStd, AggVar, AggFirst, AggLast, AggNUnique, AggMedian, AggSkew and AggKurt,
+// and the quantiles returned by AggQuantile. They work directly on the typed backing
+// slices and skip null values. NewAggFunc wraps any other function.
+type AggFunc struct {
+	// Name identifies the aggregation. Agg appends it to the column name to name its
+	// result columns, for example "age_mean".
+	Name string
+
+	// numeric is true for aggregations that only apply to int and float columns.
+	numeric bool
+	// hashed is true for aggregations that hash the values, which fail on object columns
+	// holding unhashable values.
+	hashed bool
+	// kernel computes one value per group of row positions.
+	kernel func(col Series, groups [][]int) Series
+	// err, if set, is returned instead of applying the aggregation.
+	err error
+}
+
+var (
+	// AggSum adds up the values of each group. Int columns give ints and float columns
+	// give floats; a group without values sums to 0.
+	AggSum = AggFunc{Name: "sum", numeric: true, kernel: sumKernel}
+	// AggMean computes the arithmetic mean of each group as a float.
+	AggMean = AggFunc{Name: "mean", numeric: true, kernel: floatKernel(meanOf)}
+	// AggMin takes the smallest value of each group. It applies to every orderable dtype.
+	AggMin = AggFunc{Name: "min", kernel: extremeKernel(-1)}
+	// AggMax takes the largest value of each group. It applies to every orderable dtype.
+	AggMax = AggFunc{Name: "max", kernel: extremeKernel(1)}
+	// AggCount counts the non-null values of each group.
+	AggCount = AggFunc{Name: "count", kernel: countKernel}
+	// AggStd computes the sample standard deviation (N-1 denominator) of each group. Groups
+	// with fewer than two values give null.
+	AggStd = AggFunc{Name: "std", numeric: true, kernel: floatKernel(stdOf)}
+	// AggVar computes the sample variance (N-1 denominator) of each group. Groups with fewer
+	// than two values give null.
+	AggVar = AggFunc{Name: "var", numeric: true, kernel: floatKernel(varOf)}
+	// AggFirst takes the first non-null value of each group.
+	AggFirst = AggFunc{Name: "first", kernel: firstKernel(false)}
+	// AggLast takes the last non-null value of each group.
+	AggLast = AggFunc{Name: "last", kernel: firstKernel(true)}
+	// AggNUnique counts the distinct non-null values of each group.
+	AggNUnique = AggFunc{Name: "nunique", hashed: true, kernel: nuniqueKernel}
+	// AggMedian computes the median of each group as a float.
+	AggMedian = AggFunc{Name: "median", numeric: true, kernel: floatKernel(medianOf)}
+	// AggSkew computes the sample skewness (adjusted Fisher-Pearson) of each group. Groups
+	// with fewer than three values give null.
+	AggSkew = AggFunc{Name: "skew", numeric: true, kernel: floatKernel(skewOf)}
+	// AggKurt computes the sample excess kurtosis (Fisher's definition, bias-corrected) of
+	// each group. Groups with fewer than four values give null.
+	AggKurt = AggFunc{Name: "kurt", numeric: true, kernel: floatKernel(kurtOf)}
+)
+
+// AggQuantile returns an aggregation computing the q-th quantile of each group as a float,
+// interpolating linearly between the two nearest values. q must be between 0 and 1; the
+// aggregation is named after the percentage, for example "25%" for q = 0.25.
+//
+// Example:
+//
+//	result, err := grouped.Agg(map[string][]AggFunc{"sales": {AggQuantile(0.9)}})
+//	// city | sales_90%
+func AggQuantile(q float64) AggFunc {
+	name := strconv.FormatFloat(q*100, 'g', -1, 64) + "%"
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		return AggFunc{Name: name, err: fmt.Errorf("quantile must be between 0 and 1, got %v", q)}
+	}
+	return AggFunc{Name: name, numeric: true, kernel: floatKernel(func(values []float64) (float64, bool) {
+		return quantileOf(values, q)
+	})}
+}
+
+// NewAggFunc creates a custom aggregation named name.
+//
+// fn receives the values of one group as a Series, nulls included, and returns the
+// aggregated value, or nil for null. The results of all groups are combined with
+// NewSeries, so fn should return values of a single type. fn may be called concurrently
+// for different columns.
+//
+// Example:
+//
+//	spread := NewAggFunc("spread", func(values Series) any {
+//	    ages := values.(*IntCol)
+//	    var present []int64
+//	    for i, age := range ages.Values() {
+//	        if !ages.IsNull(i) { // null slots hold the zero value
+//	            present = append(present, age)
+//	        }
+//	    }
+//	    if len(present) == 0 {
+//	        return nil
+//	    }
+//	    return slices.Max(present) - slices.Min(present)
+//	})
+//	result, err := grouped.Agg(map[string][]AggFunc{"age": {spread}})
+func NewAggFunc(name string, fn func(values Series) any) AggFunc {
+	return AggFunc{
+		Name: name,
+		kernel: func(col Series, groups [][]int) Series {
+			values := make([]any, len(groups))
+			for g, rows := range groups {
+				values[g] = fn(col.Take(rows))
+			}
+			return NewSeries(values)
+		},
+	}
+}
+
+// apply runs the aggregation over col, returning an error if it needs numeric values and
+// col holds another dtype.
+func (f AggFunc) apply(col Series, groups [][]int) (Series, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.kernel == nil {
+		return nil, fmt.Errorf("aggregation %q has no function", f.Name)
+	}
+	if f.numeric && !isNumeric(col) {
+		return nil, fmt.Errorf("cannot compute %s of a %s column", f.Name, col.DType())
+	}
+	if f.hashed {
+		if err := checkHashable(col); err != nil {
+			return nil, fmt.Errorf("cannot compute %s: %w", f.Name, err)
+		}
+	}
+	return f.kernel(col, groups), nil
+}
+
+// isNumeric reports whether col holds ints or floats.
+func isNumeric(col Series) bool {
+	return col.DType() == IntType || col.DType() == FloatType
+}
+
+// numericAccessor returns a function reading position i of an int or float column as a
+// float64.
+func numericAccessor(col Series) func(i int) float64 {
+	switch c := col.(type) {
+	case *FloatCol:
+		return func(i int) float64 { return c.data[i] }
+	case *IntCol:
+		return func(i int) float64 { return float64(c.data[i]) }
+	default:
+		return func(i int) float64 {
+			v, _ := col.At(i).(float64)
+			return v
+		}
+	}
+}
+
+// sumKernel sums every group, keeping int columns as ints.
+func sumKernel(col Series, groups [][]int) Series {
+	if ints, ok := col.(*IntCol); ok {
+		sums := make([]int64, len(groups))
+		for g, rows := range groups {
+			for _, r := range rows {
+				if !ints.IsNull(r) {
+					sums[g] += ints.data[r]
+				}
+			}
+		}
+		return NewIntCol(sums)
+	}
+	return floatKernel(func(values []float64) (float64, bool) {
+		sum := 0.0
+		for _, v := range values {
+			sum += v
+		}
+		return sum, true
+	})(col, groups)
+}
+
+// floatKernel returns a kernel that gathers the non-null values of each group as floats
+// and reduces them with reduce. A group for which reduce reports false is null.
+func floatKernel(reduce func(values []float64) (float64, bool)) func(Series, [][]int) Series {
+	return func(col Series, groups [][]int) Series {
+		at := numericAccessor(col)
+		out := NewFloatCol(make([]float64, len(groups)))
+		var buf []float64
+		for g, rows := range groups {
+			buf = buf[:0]
+			for _, r := range rows {
+				if !col.IsNull(r) {
+					buf = append(buf, at(r))
+				}
+			}
+			v, ok := reduce(buf)
+			if !ok {
+				out.SetNull(g)
+				continue
+			}
+			out.data[g] = v
+		}
+		return out
+	}
+}
+
+// meanOf returns the arithmetic mean of values, or false if there are none.
+func meanOf(values []float64) (float64, bool) {
+	if len(values) == 0 {
+		return 0, false
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values)), true
+}
+
+// varOf returns the sample variance of values using Welford's algorithm, or false if there
+// are fewer than two values.
+func varOf(values []float64) (float64, bool) {
+	if len(values) < 2 {
+		return 0, false
+	}
+	mean, m2 := 0.0, 0.0
+	for i, v := range values {
+		delta := v - mean
+		mean += delta / float64(i+1)
+		m2 += delta * (v - mean)
+	}
+	return m2 / float64(len(values)-1), true
+}
+
+// stdOf returns the sample standard deviation of values, or false if there are fewer than
+// two values.
+func stdOf(values []float64) (float64, bool) {
+	v, ok := varOf(values)
+	return math.Sqrt(v), ok
+}
+
+// medianOf returns the median of values, or false if there are none. values is sorted in
+// place.
+func medianOf(values []float64) (float64, bool) {
+	n := len(values)
+	if n == 0 {
+		return 0, false
+	}
+	slices.Sort(values)
+	if n%2 == 1 {
+		return values[n/2], true
+	}
+	return (values[n/2-1] + values[n/2]) / 2, true
+}
+
+// quantileOf returns the q-th quantile of values with linear interpolation, or false if
+// there are none. values is sorted in place.
+func quantileOf(values []float64, q float64) (float64, bool) {
+	n := len(values)
+	if n == 0 {
+		return 0, false
+	}
+	slices.Sort(values)
+	pos := q * float64(n-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	return values[lo] + (values[hi]-values[lo])*(pos-float64(lo)), true
+}
+
+// centralMoments returns the mean of values and the sums of their squared, cubed and
+// fourth-power deviations from it.
+func centralMoments(values []float64) (mean, s2, s3, s4 float64) {
+	mean, _ = meanOf(values)
+	for _, v := range values {
+		d := v - mean
+		d2 := d * d
+		s2 += d2
+		s3 += d2 * d
+		s4 += d2 * d2
+	}
+	return mean, s2, s3, s4
+}
+
+// skewOf returns the adjusted Fisher-Pearson skewness of values, or false if there are
+// fewer than three values. Constant values have a skewness of 0.
+func skewOf(values []float64) (float64, bool) {
+	n := float64(len(values))
+	if n < 3 {
+		return 0, false
+	}
+	_, s2, s3, _ := centralMoments(values)
+	if s2 == 0 {
+		return 0, true
+	}
+	m2, m3 := s2/n, s3/n
+	return m3 / math.Pow(m2, 1.5) * math.Sqrt(n*(n-1)) / (n - 2), true
+}
+
+// kurtOf returns the bias-corrected excess kurtosis of values, or false if there are fewer
+// than four values. Constant values have a kurtosis of 0.
+func kurtOf(values []float64) (float64, bool) {
+	n := float64(len(values))
+	if n < 4 {
+		return 0, false
+	}
+	_, s2, _, s4 := centralMoments(values)
+	if s2 == 0 {
+		return 0, true
+	}
+	numerator := n * (n + 1) * (n - 1) * s4
+	denominator := (n - 2) * (n - 3) * s2 * s2
+	return numerator/denominator - 3*(n-1)*(n-1)/((n-2)*(n-3)), true
+}
+
+// extremeKernel returns a kernel taking the smallest (sign -1) or largest (sign 1) non-null
+// value of each group. The result keeps the dtype of the column.
+func extremeKernel(sign int) func(Series, [][]int) Series {
+	return func(col Series, groups [][]int) Series {
+		compare := columnComparator(col)
+		picks := make([]int, len(groups))
+		for g, rows := range groups {
+			picks[g] = -1
+			for _, r := range rows {
+				if col.IsNull(r) {
+					continue
+				}
+				if picks[g] < 0 || compare(r, picks[g])*sign > 0 {
+					picks[g] = r
+				}
+			}
+		}
+		return col.Take(picks)
+	}
+}
+
+// firstKernel returns a kernel taking the first (or, if last is true, the last) non-null
+// value of each group. The result keeps the dtype of the column.
+func firstKernel(last bool) func(Series, [][]int) Series {
+	return func(col Series, groups [][]int) Series {
+		picks := make([]int, len(groups))
+		for g, rows := range groups {
+			picks[g] = -1
+			for i := range rows {
+				r := rows[i]
+				if last {
+					r = rows[len(rows)-1-i]
+				}
+				if !col.IsNull(r) {
+					picks[g] = r
+					break
+				}
+			}
+		}
+		return col.Take(picks)
+	}
+}
+
+// countKernel counts the non-null values of every group.
+func countKernel(col Series, groups [][]int) Series {
+	counts := make([]int64, len(groups))
+	for g, rows := range groups {
+		for _, r := range rows {
+			if !col.IsNull(r) {
+				counts[g]++
+			}
+		}
+	}
+	return NewIntCol(counts)
+}
+
+// nuniqueKernel counts the distinct non-null values of every group. The column is
+// factorized once, after which a group counts each code the first time it sees it.
+// apply has checked that the values can be hashed.
+func nuniqueKernel(col Series, groups [][]int) Series {
+	codes, cardinality := factorize(col)
+	seenBy := make([]int, cardinality)
+	for i := range seenBy {
+		seenBy[i] = -1
+	}
+	counts := make([]int64, len(groups))
+	for g, rows := range groups {
+		for _, r := range rows {
+			if code := codes[r]; code >= 0 && seenBy[code] != g {
+				seenBy[code] = g
+				counts[g]++
+			}
+		}
+	}
+	return NewIntCol(counts)
+}
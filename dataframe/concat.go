@@ -0,0 +1,522 @@
+package dataframe
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ConcatAxis selects the direction in which Concat combines DataFrames.
+type ConcatAxis string
+
+const (
+	// ConcatRows stacks DataFrames vertically, aligning their columns by name.
+	ConcatRows ConcatAxis = "rows"
+	// ConcatColumns places DataFrames side by side, aligning their rows by index label.
+	ConcatColumns ConcatAxis = "columns"
+)
+
+// ConcatJoin selects which labels of the other axis a Concat keeps: column names when
+// stacking rows, index labels when placing columns side by side.
+type ConcatJoin string
+
+const (
+	// ConcatOuter keeps the labels present in any DataFrame, filling the gaps with nulls.
+	ConcatOuter ConcatJoin = "outer"
+	// ConcatInner keeps only the labels present in every DataFrame.
+	ConcatInner ConcatJoin = "inner"
+)
+
+// DefaultConcatKeyColumn is the name of the column added by ConcatOptions.Keys when
+// ConcatOptions.KeyColumn is not set.
+const DefaultConcatKeyColumn = "_source"
+
+// ConcatOptions configures ConcatWith.
+type ConcatOptions struct {
+	// Axis is the direction to combine in. The zero value stacks rows (ConcatRows).
+	Axis ConcatAxis
+
+	// Join selects the labels kept on the other axis. The zero value is ConcatOuter.
+	Join ConcatJoin
+
+	// IgnoreIndex gives the result a default RangeIndex. When stacking rows the index
+	// labels of the inputs are dropped; when placing columns side by side rows are
+	// aligned by position instead of by label.
+	IgnoreIndex bool
+
+	// Keys tags every row with the key of the DataFrame it comes from, one key per
+	// DataFrame, in a string column appended to the result. Only valid for ConcatRows.
+	Keys []string
+	// KeyColumn names the column holding Keys. Defaults to DefaultConcatKeyColumn.
+	KeyColumn string
+}
+
+// Concat combines DataFrames into one, either by stacking their rows or by placing their
+// columns side by side.
+//
+// Concat is a shorthand for ConcatWith with only the axis and join set; use ConcatWith to
+// drop the index labels or tag rows with their source.
+//
+// Parameters:
+//   - frames: the DataFrames to combine, in order
+//   - axis: ConcatRows to stack rows, aligning columns by name, or ConcatColumns to place
+//     columns side by side, aligning rows by index label
+//   - join: ConcatOuter to keep every column (or row label) and fill the gaps with nulls,
+//     or ConcatInner to keep only those shared by every DataFrame
+//
+// Returns:
+//   - A new DataFrame holding the combined data
+//   - An error if there are no DataFrames, a DataFrame is nil or the inputs cannot be
+//     aligned; see ConcatWith
+//
+// Example:
+//
+//	// monday has columns date, sales; tuesday has columns date, sales, returns
+//	week, err := Concat([]*DataFrame{monday, tuesday}, ConcatRows, ConcatOuter)
+//	// date       | sales | returns
+//	// 2024-01-01 | 120   | NaN
+//	// 2024-01-02 | 98.5  | 3
+func Concat(frames []*DataFrame, axis ConcatAxis, join ConcatJoin) (*DataFrame, error) {
+	return ConcatWith(frames, ConcatOptions{Axis: axis, Join: join})
+}
+
+// ConcatWith combines DataFrames as described by opts.
+//
+// Stacking rows (ConcatRows) aligns columns by name. The result has the columns of every
+// DataFrame in order of first appearance (ConcatOuter) or the columns of the first
+// DataFrame that every other one has too (ConcatInner); a DataFrame without one of the
+// columns contributes nulls to it. Each column keeps its type when every DataFrame agrees
+// on it; int and float columns combine into a float column, and any other mix gives an
+// object column. The index labels are stacked as well, unless IgnoreIndex is set.
+//
+// Placing columns side by side (ConcatColumns) aligns rows by index label, which must be
+// unique and non-null in every DataFrame. The result has the labels of every DataFrame in
+// order of first appearance (ConcatOuter) or the labels of the first DataFrame that every
+// other one has too (ConcatInner). With IgnoreIndex, rows are aligned by position instead.
+// Column names must not repeat across the DataFrames.
+//
+// Parameters:
+//   - frames: the DataFrames to combine, in order
+//   - opts: the axis, join and row tagging; see ConcatOptions
+//
+// Returns:
+//   - A new DataFrame holding the combined data
+//   - An error if there are no DataFrames, a DataFrame is nil, an option is invalid,
+//     column names repeat, or index labels repeat or are null when aligning rows by label
+//
+// Example:
+//
+//	// union daily extracts, remembering which file each row came from
+//	all, err := ConcatWith(days, ConcatOptions{
+//	    Keys:        []string{"2024-01-01", "2024-01-02"},
+//	    KeyColumn:   "day",
+//	    IgnoreIndex: true,
+//	})
+//	// date       | sales | day
+//	// 2024-01-01 | 120   | 2024-01-01
+//	// 2024-01-02 | 98.5  | 2024-01-02
+func ConcatWith(frames []*DataFrame, opts ConcatOptions) (*DataFrame, error) {
+	if len(frames) == 0 {
+		return nil, errors.New("no DataFrames to concatenate")
+	}
+	switch opts.Join {
+	case "":
+		opts.Join = ConcatOuter
+	case ConcatOuter, ConcatInner:
+	default:
+		return nil, fmt.Errorf("invalid concat join: %s", opts.Join)
+	}
+	if opts.Keys != nil && len(opts.Keys) != len(frames) {
+		return nil, fmt.Errorf("got %d keys for %d DataFrames", len(opts.Keys), len(frames))
+	}
+
+	// Work on snapshots, so that each DataFrame is locked only while it is copied
+	snapshots := make([]*DataFrame, len(frames))
+	for i, frame := range frames {
+		if frame == nil {
+			return nil, fmt.Errorf("DataFrame %d is nil", i)
+		}
+		frame.Lock()
+		snapshots[i] = &DataFrame{
+			Columns: append([]string(nil), frame.Columns...),
+			Data:    append([]Series(nil), frame.Data...),
+			Index:   frame.Index,
+		}
+		frame.Unlock()
+		if err := checkUniqueColumns(snapshots[i].Columns); err != nil {
+			return nil, fmt.Errorf("DataFrame %d: %w", i, err)
+		}
+	}
+
+	switch opts.Axis {
+	case "", ConcatRows:
+		return concatAlignedRows(snapshots, opts)
+	case ConcatColumns:
+		if opts.Keys != nil {
+			return nil, errors.New("Keys can only be used when stacking rows")
+		}
+		return concatColumns(snapshots, opts)
+	default:
+		return nil, fmt.Errorf("invalid concat axis: %s", opts.Axis)
+	}
+}
+
+// concatAlignedRows stacks frames vertically for ConcatWith, aligning columns by name.
+func concatAlignedRows(frames []*DataFrame, opts ConcatOptions) (*DataFrame, error) {
+	columns := joinLabels(len(frames), opts.Join, func(i int) []any {
+		names := make([]any, len(frames[i].Columns))
+		for c, name := range frames[i].Columns {
+			names[c] = name
+		}
+		return names
+	})
+
+	out := &DataFrame{
+		Columns: make([]string, 0, len(columns)+1),
+		Data:    make([]Series, 0, len(columns)+1),
+	}
+	parts := make([]Series, len(frames))
+	for _, name := range columns {
+		// Frames without the column contribute nulls of the type of the first frame that
+		// has it
+		var like Series
+		for i, frame := range frames {
+			parts[i] = nil
+			if pos := slices.Index(frame.Columns, name.(string)); pos >= 0 {
+				parts[i] = frame.Data[pos]
+				if like == nil {
+					like = parts[i]
+				}
+			}
+		}
+		for i, frame := range frames {
+			if parts[i] == nil {
+				parts[i] = nullSeriesLike(like, frame.rowCount())
+			}
+		}
+		out.Columns = append(out.Columns, name.(string))
+		out.Data = append(out.Data, concatSeries(parts))
+	}
+
+	if opts.Keys != nil {
+		keyColumn := opts.KeyColumn
+		if keyColumn == "" {
+			keyColumn = DefaultConcatKeyColumn
+		}
+		if slices.Contains(out.Columns, keyColumn) {
+			return nil, fmt.Errorf("cannot add the key column '%s': a column with that name already exists", keyColumn)
+		}
+		var keys []string
+		for i, frame := range frames {
+			for n := frame.rowCount(); n > 0; n-- {
+				keys = append(keys, opts.Keys[i])
+			}
+		}
+		out.Columns = append(out.Columns, keyColumn)
+		out.Data = append(out.Data, NewStringCol(keys))
+	}
+
+	if !opts.IgnoreIndex {
+		name := frames[0].RowIndex().Name()
+		for i, frame := range frames {
+			parts[i] = frame.RowIndex().Labels()
+			if frame.RowIndex().Name() != name {
+				name = ""
+			}
+		}
+		out.Index = NewLabelIndex(name, concatSeries(parts))
+	}
+	return out, nil
+}
+
+// concatColumns places frames side by side for ConcatWith, aligning rows by index label
+// or, with IgnoreIndex, by position.
+func concatColumns(frames []*DataFrame, opts ConcatOptions) (*DataFrame, error) {
+	var names []string
+	for _, frame := range frames {
+		names = append(names, frame.Columns...)
+	}
+	if err := checkUniqueColumns(names); err != nil {
+		return nil, fmt.Errorf("cannot place DataFrames side by side: %w", err)
+	}
+
+	out := &DataFrame{Columns: names}
+	if opts.IgnoreIndex {
+		rows := frames[0].rowCount()
+		for _, frame := range frames[1:] {
+			if opts.Join == ConcatOuter {
+				rows = max(rows, frame.rowCount())
+			} else {
+				rows = min(rows, frame.rowCount())
+			}
+		}
+		for _, frame := range frames {
+			positions := make([]int, rows)
+			for i := range positions {
+				positions[i] = i
+				if i >= frame.rowCount() {
+					positions[i] = -1
+				}
+			}
+			for _, col := range frame.Data {
+				out.Data = append(out.Data, col.Take(positions))
+			}
+		}
+		return out, nil
+	}
+
+	// Index labels must identify a single row of every frame
+	lookups := make([]map[any]int, len(frames))
+	for i, frame := range frames {
+		index := frame.RowIndex()
+		if err := checkHashableKeys(index.Labels()); err != nil {
+			return nil, fmt.Errorf("DataFrame %d: %w", i, err)
+		}
+		lookups[i] = make(map[any]int, index.Len())
+		for row := 0; row < index.Len(); row++ {
+			label := normalizeKey(index.Label(row))
+			if label == nil {
+				return nil, fmt.Errorf("DataFrame %d has a null index label at row %d", i, row)
+			}
+			if _, ok := lookups[i][label]; ok {
+				return nil, fmt.Errorf("DataFrame %d has the index label %v more than once", i, index.Label(row))
+			}
+			lookups[i][label] = row
+		}
+	}
+
+	labels := joinLabels(len(frames), opts.Join, func(i int) []any {
+		index := frames[i].RowIndex()
+		labels := make([]any, index.Len())
+		for row := range labels {
+			labels[row] = index.Label(row)
+		}
+		return labels
+	})
+	for i, frame := range frames {
+		positions := make([]int, len(labels))
+		for row, label := range labels {
+			positions[row] = -1
+			if pos, ok := lookups[i][normalizeKey(label)]; ok {
+				positions[row] = pos
+			}
+		}
+		for _, col := range frame.Data {
+			out.Data = append(out.Data, col.Take(positions))
+		}
+	}
+
+	// Frames that all use the default RangeIndex align to another RangeIndex
+	ranged := true
+	name := frames[0].RowIndex().Name()
+	for _, frame := range frames {
+		ranged = ranged && frame.Index == nil
+		if frame.RowIndex().Name() != name {
+			name = ""
+		}
+	}
+	if !ranged {
+		out.Index = NewLabelIndex(name, NewSeries(labels))
+	}
+	return out, nil
+}
+
+// joinLabels combines the labels of n sequences, returned by labels(i), by value. An
+// outer join keeps every label in order of first appearance; an inner join keeps the
+// labels of the first sequence that every other sequence has too.
+func joinLabels(n int, join ConcatJoin, labels func(i int) []any) []any {
+	first := labels(0)
+	if join == ConcatInner {
+		kept := first
+		for i := 1; i < n; i++ {
+			present := make(map[any]bool)
+			for _, label := range labels(i) {
+				present[normalizeKey(label)] = true
+			}
+			kept = slices.DeleteFunc(kept, func(label any) bool { return !present[normalizeKey(label)] })
+		}
+		return kept
+	}
+
+	seen := make(map[any]bool)
+	var joined []any
+	for i := 0; i < n; i++ {
+		labelsOf := first
+		if i > 0 {
+			labelsOf = labels(i)
+		}
+		for _, label := range labelsOf {
+			if key := normalizeKey(label); !seen[key] {
+				seen[key] = true
+				joined = append(joined, label)
+			}
+		}
+	}
+	return joined
+}
+
+// nullSeriesLike returns a Series of n nulls of the same type as like.
+func nullSeriesLike(like Series, n int) Series {
+	positions := make([]int, n)
+	for i := range positions {
+		positions[i] = -1
+	}
+	return like.Take(positions)
+}
+
+// concatSeries stacks parts into a single Series, in order.
+//
+// When every part has the same column type the result keeps it and values are copied
+// without boxing. Int and float parts together give a FloatCol. Otherwise the values are
+// boxed and passed to NewSeries, which picks the narrowest type holding all of them.
+// Empty parts do not take part in choosing the type.
+func concatSeries(parts []Series) Series {
+	if len(parts) == 0 {
+		return NewObjectCol(nil)
+	}
+	nonEmpty := make([]Series, 0, len(parts))
+	for _, part := range parts {
+		if part.Len() > 0 {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	if len(nonEmpty) == 0 {
+		return parts[0].Copy()
+	}
+	parts = promoteNumeric(nonEmpty)
+
+	var out Series
+	var ok bool
+	switch parts[0].(type) {
+	case *FloatCol:
+		out, ok = concatTyped[float64](parts)
+	case *IntCol:
+		out, ok = concatTyped[int64](parts)
+	case *StringCol:
+		out, ok = concatTyped[string](parts)
+	case *BoolCol:
+		out, ok = concatTyped[bool](parts)
+	case *DateTimeCol:
+		out, ok = concatTyped[time.Time](parts)
+	case *ObjectCol:
+		out, ok = concatTyped[any](parts)
+	}
+	if ok {
+		return out
+	}
+
+	var values []any
+	for _, part := range parts {
+		for i := 0; i < part.Len(); i++ {
+			values = append(values, part.At(i))
+		}
+	}
+	return NewSeries(values)
+}
+
+// promoteNumeric returns parts with every IntCol converted to a FloatCol when the parts
+// mix IntCol and FloatCol and hold no other column type. Other parts are returned as is.
+func promoteNumeric(parts []Series) []Series {
+	floats := false
+	for _, part := range parts {
+		switch part.(type) {
+		case *FloatCol:
+			floats = true
+		case *IntCol:
+		default:
+			return parts
+		}
+	}
+	if !floats {
+		return parts
+	}
+
+	promoted := make([]Series, len(parts))
+	for i, part := range parts {
+		ints, ok := part.(*IntCol)
+		if !ok {
+			promoted[i] = part
+			continue
+		}
+		values := make([]float64, len(ints.data))
+		for j, v := range ints.data {
+			values[j] = float64(v)
+		}
+		col := NewFloatCol(values)
+		for j := range values {
+			if ints.IsNull(j) {
+				col.SetNull(j)
+			}
+		}
+		promoted[i] = col
+	}
+	return promoted
+}
+
+// concatTyped stacks parts that are all TypeColumn[T]. It reports false if any part has a
+// different type.
+func concatTyped[T comparable](parts []Series) (Series, bool) {
+	total := 0
+	for _, part := range parts {
+		if _, ok := part.(*TypeColumn[T]); !ok {
+			return nil, false
+		}
+		total += part.Len()
+	}
+
+	out := &TypeColumn[T]{data: make([]T, 0, total)}
+	for _, part := range parts {
+		out.data = append(out.data, part.(*TypeColumn[T]).data...)
+	}
+	offset := 0
+	for _, part := range parts {
+		col := part.(*TypeColumn[T])
+		if col.valid != nil {
+			for i := range col.data {
+				if col.IsNull(i) {
+					out.SetNull(offset + i)
+				}
+			}
+		}
+		offset += len(col.data)
+	}
+	return out, true
+}
+
+// concatRows stacks frames that share the same column names vertically, in order. The
+// index labels of the frames are stacked as well; the result keeps the index name only if
+// every frame has the same one.
+func concatRows(frames []*DataFrame) (*DataFrame, error) {
+	if len(frames) == 0 {
+		return nil, errors.New("no DataFrames to concatenate")
+	}
+	columns := frames[0].Columns
+	for _, frame := range frames[1:] {
+		if !slices.Equal(frame.Columns, columns) {
+			return nil, fmt.Errorf("cannot stack DataFrames with columns %v and %v", columns, frame.Columns)
+		}
+	}
+
+	out := &DataFrame{
+		Columns: append([]string(nil), columns...),
+		Data:    make([]Series, len(columns)),
+	}
+	parts := make([]Series, len(frames))
+	for c := range columns {
+		for i, frame := range frames {
+			parts[i] = frame.Data[c]
+		}
+		out.Data[c] = concatSeries(parts)
+	}
+
+	name := frames[0].RowIndex().Name()
+	for i, frame := range frames {
+		parts[i] = frame.RowIndex().Labels()
+		if frame.RowIndex().Name() != name {
+			name = ""
+		}
+	}
+	out.Index = NewLabelIndex(name, concatSeries(parts))
+	return out, nil
+}
@@ -0,0 +1,296 @@
+package dataframe
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// CorrMethod selects the correlation coefficient computed by Corr.
+type CorrMethod string
+
+const (
+	// CorrPearson measures the linear correlation of the values.
+	CorrPearson CorrMethod = "pearson"
+	// CorrSpearman is the Pearson correlation of the ranks of the values, with tied values
+	// sharing their average rank.
+	CorrSpearman CorrMethod = "spearman"
+	// CorrKendall is Kendall's tau-b, which counts concordant and discordant pairs of rows
+	// and corrects for ties.
+	CorrKendall CorrMethod = "kendall"
+)
+
+// MatrixIndexName is the name of the index of the matrices returned by Corr and Cov,
+// whose labels repeat the column names.
+const MatrixIndexName = "column"
+
+// Corr returns the correlation matrix of the int and float columns of the DataFrame.
+// Other columns are left out.
+//
+// Each pair of columns is correlated over the rows where both hold a value, so a null
+// only removes its row from the pairs that involve its column. A pair with fewer than two
+// such rows, or where either column is constant over them, gives null. Column pairs are
+// computed concurrently.
+//
+// Parameters:
+//   - method: CorrPearson, CorrSpearman or CorrKendall; empty means CorrPearson
+//
+// Returns:
+//   - A new square DataFrame with one float column and one row per numeric column, in
+//     order. Its index, named MatrixIndexName ("column"), holds the column names.
+//   - An error if the DataFrame is nil or the method is invalid.
+//
+// Example:
+//
+//	matrix, err := df.Corr(CorrSpearman)
+//	// column | height | weight
+//	// height | 1      | 0.83
+//	// weight | 0.83   | 1
+func (df *DataFrame) Corr(method CorrMethod) (*DataFrame, error) {
+	var pair func(x, y []float64) (float64, bool)
+	switch method {
+	case "", CorrPearson:
+		pair = pearsonOf
+	case CorrSpearman:
+		pair = func(x, y []float64) (float64, bool) { return pearsonOf(ranksOf(x), ranksOf(y)) }
+	case CorrKendall:
+		pair = kendallOf
+	default:
+		return nil, fmt.Errorf("invalid correlation method: %s", method)
+	}
+	return df.pairwise(pair)
+}
+
+// Cov returns the covariance matrix of the int and float columns of the DataFrame, using
+// the sample covariance (N-1 denominator). Other columns are left out.
+//
+// Each pair of columns is compared over the rows where both hold a value; a pair with
+// fewer than two such rows gives null. The diagonal holds the variance of each column.
+// Column pairs are computed concurrently.
+//
+// Returns:
+//   - A new square DataFrame with one float column and one row per numeric column, in
+//     order. Its index, named MatrixIndexName ("column"), holds the column names.
+//   - An error if the DataFrame is nil.
+//
+// Example:
+//
+//	matrix, err := df.Cov()
+//	// column | height | weight
+//	// height | 72.5   | 41.2
+//	// weight | 41.2   | 30.1
+func (df *DataFrame) Cov() (*DataFrame, error) {
+	return df.pairwise(covOf)
+}
+
+// pairwise computes stat for every pair of numeric columns over their pairwise-complete
+// rows and arranges the results in a symmetric matrix. Pairs are split among goroutines.
+func (df *DataFrame) pairwise(stat func(x, y []float64) (float64, bool)) (*DataFrame, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+	var names []string
+	var cols []Series
+	for pos, col := range df.Data {
+		if isNumeric(col) {
+			names = append(names, df.Columns[pos])
+			cols = append(cols, col)
+		}
+	}
+
+	type pair struct{ a, b int }
+	var pairs []pair
+	for a := range cols {
+		for b := a; b < len(cols); b++ {
+			pairs = append(pairs, pair{a, b})
+		}
+	}
+
+	// Each pair writes only its own slot, so the goroutines never share a write
+	values := make([]float64, len(pairs))
+	valid := make([]bool, len(pairs))
+	parallelChunks(splitRows(len(pairs)), func(_, start, end int) {
+		for k := start; k < end; k++ {
+			x, y := completeRows(cols[pairs[k].a], cols[pairs[k].b])
+			v, ok := stat(x, y)
+			values[k], valid[k] = v, ok && !math.IsNaN(v)
+		}
+	})
+
+	matrix := make([]*FloatCol, len(cols))
+	for c := range matrix {
+		matrix[c] = NewFloatCol(make([]float64, len(cols)))
+	}
+	for k, p := range pairs {
+		if !valid[k] {
+			matrix[p.a].SetNull(p.b)
+			matrix[p.b].SetNull(p.a)
+			continue
+		}
+		matrix[p.a].data[p.b] = values[k]
+		matrix[p.b].data[p.a] = values[k]
+	}
+
+	out := &DataFrame{
+		Columns: names,
+		Data:    make([]Series, len(cols)),
+		Index:   NewLabelIndex(MatrixIndexName, NewStringCol(slices.Clone(names))),
+	}
+	for c, col := range matrix {
+		out.Data[c] = col
+	}
+	return out, nil
+}
+
+// completeRows returns the values of two numeric columns at the rows where both hold a
+// value, as floats.
+func completeRows(a, b Series) (x, y []float64) {
+	atA, atB := numericAccessor(a), numericAccessor(b)
+	n := a.Len()
+	x, y = make([]float64, 0, n), make([]float64, 0, n)
+	for i := 0; i < n; i++ {
+		if !a.IsNull(i) && !b.IsNull(i) {
+			x = append(x, atA(i))
+			y = append(y, atB(i))
+		}
+	}
+	return x, y
+}
+
+// covOf returns the sample covariance of x and y, or false if there are fewer than two
+// values.
+func covOf(x, y []float64) (float64, bool) {
+	if len(x) < 2 {
+		return 0, false
+	}
+	meanX, _ := meanOf(x)
+	meanY, _ := meanOf(y)
+	sum := 0.0
+	for i := range x {
+		sum += (x[i] - meanX) * (y[i] - meanY)
+	}
+	return sum / float64(len(x)-1), true
+}
+
+// pearsonOf returns the Pearson correlation of x and y, or false if there are fewer than
+// two values or either is constant.
+func pearsonOf(x, y []float64) (float64, bool) {
+	if len(x) < 2 {
+		return 0, false
+	}
+	meanX, _ := meanOf(x)
+	meanY, _ := meanOf(y)
+	var sxy, sxx, syy float64
+	for i := range x {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+	if sxx == 0 || syy == 0 {
+		return 0, false
+	}
+	// Clamp the rounding error of perfectly correlated values
+	return math.Max(-1, math.Min(1, sxy/math.Sqrt(sxx*syy))), true
+}
+
+// ranksOf returns the 1-based ranks of values, giving tied values their average rank.
+func ranksOf(values []float64) []float64 {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int { return cmp.Compare(values[a], values[b]) })
+
+	ranks := make([]float64, len(values))
+	for start := 0; start < len(order); {
+		end := start + 1
+		for end < len(order) && values[order[end]] == values[order[start]] {
+			end++
+		}
+		// positions start..end-1 share ranks start+1..end
+		rank := float64(start+1+end) / 2
+		for _, i := range order[start:end] {
+			ranks[i] = rank
+		}
+		start = end
+	}
+	return ranks
+}
+
+// kendallOf returns Kendall's tau-b of x and y, or false if there are fewer than two
+// values or either is constant.
+//
+// It uses Knight's O(n log n) algorithm: the rows are sorted by x and then y, which makes
+// the number of discordant pairs equal to the number of swaps a merge sort by y needs.
+// Pairs tied in x, in y and in both are counted from runs of equal values.
+func kendallOf(x, y []float64) (float64, bool) {
+	n := len(x)
+	if n < 2 {
+		return 0, false
+	}
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int {
+		return cmp.Or(cmp.Compare(x[a], x[b]), cmp.Compare(y[a], y[b]))
+	})
+	tiedX := tiedPairs(order, func(a, b int) bool { return x[a] == x[b] })
+	tiedXY := tiedPairs(order, func(a, b int) bool { return x[a] == x[b] && y[a] == y[b] })
+
+	swaps := countSwaps(order, make([]int, n), func(a, b int) bool { return y[a] > y[b] })
+	tiedY := tiedPairs(order, func(a, b int) bool { return y[a] == y[b] })
+
+	total := int64(n) * int64(n-1) / 2
+	if total == tiedX || total == tiedY {
+		return 0, false
+	}
+	concordantMinusDiscordant := float64(total - tiedX - tiedY + tiedXY - 2*swaps)
+	return concordantMinusDiscordant / math.Sqrt(float64(total-tiedX)*float64(total-tiedY)), true
+}
+
+// tiedPairs counts the pairs of rows within runs of consecutive rows of order that are
+// equal by same.
+func tiedPairs(order []int, same func(a, b int) bool) int64 {
+	var pairs int64
+	for start := 0; start < len(order); {
+		end := start + 1
+		for end < len(order) && same(order[start], order[end]) {
+			end++
+		}
+		run := int64(end - start)
+		pairs += run * (run - 1) / 2
+		start = end
+	}
+	return pairs
+}
+
+// countSwaps merge-sorts rows so that no row is followed by one it is greater than, using
+// buf as scratch space of the same length, and returns the number of inversions it
+// removed.
+func countSwaps(rows, buf []int, greater func(a, b int) bool) int64 {
+	if len(rows) < 2 {
+		return 0
+	}
+	mid := len(rows) / 2
+	swaps := countSwaps(rows[:mid], buf[:mid], greater) + countSwaps(rows[mid:], buf[mid:], greater)
+	i, j, k := 0, mid, 0
+	for i < mid && j < len(rows) {
+		if greater(rows[i], rows[j]) {
+			buf[k] = rows[j]
+			swaps += int64(mid - i)
+			j++
+		} else {
+			buf[k] = rows[i]
+			i++
+		}
+		k++
+	}
+	k += copy(buf[k:], rows[i:mid])
+	copy(buf[k:], rows[j:])
+	copy(rows, buf[:len(rows)])
+	return swaps
+}
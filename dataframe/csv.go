@@ -0,0 +1,386 @@
+package dataframe
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// QuoteMode selects which CSV fields WriteCSV encloses in quotes.
+type QuoteMode string
+
+const (
+	// QuoteMinimal quotes only fields that contain the separator, a quote, a line break or
+	// leading whitespace, as encoding/csv does, and an empty field that is the only field
+	// of its record. It is the default.
+	QuoteMinimal QuoteMode = "minimal"
+	// QuoteAll quotes every field, including the header and nulls.
+	QuoteAll QuoteMode = "all"
+	// QuoteNonNumeric quotes every field except numbers and nulls, so readers can tell
+	// numbers from text by their quoting.
+	QuoteNonNumeric QuoteMode = "nonnumeric"
+	// QuoteNone never quotes. Writing a field that contains the separator, a quote or a
+	// line break, or an empty field that is the only field of its record, is an error.
+	QuoteNone QuoteMode = "none"
+)
+
+// ToCSVOptions configures how a DataFrame is written as CSV.
+//
+// The output follows RFC 4180: fields are enclosed in double quotes according to Quoting,
+// and quotes inside a quoted field are escaped by doubling them, so Read_csv reads the
+// values back unchanged.
+//
+// Fields:
+//   - Separator: separator placed between fields (defaults to comma when empty). It must
+//     not contain a quote or a line break.
+//   - NullRep: text written for null values (defaults to an empty field)
+//   - Quoting: which fields to quote (defaults to QuoteMinimal)
+//   - LineTerminator: text written after every record (defaults to "\n")
+//   - OmitHeader: do not write the header record
+//   - Index: write the index label of every row as its first field (the row position for
+//     the default RangeIndex)
+//   - IndexLabel: header of the index field when Index is set (defaults to the name of the
+//     index, which is empty for the default RangeIndex)
+//   - Columns: names of the columns to write, in order (defaults to every column)
+//   - FloatFormat: fmt verb used for float64 values, e.g. "%.2f" (defaults to the shortest
+//     representation that reads back to the same value)
+type ToCSVOptions struct {
+	Separator      string
+	NullRep        string
+	Quoting        QuoteMode
+	LineTerminator string
+	OmitHeader     bool
+	Index          bool
+	IndexLabel     string
+	Columns        []string
+	FloatFormat    string
+}
+
+// ToCSV converts the DataFrame to a CSV string representation or writes it to a file.
+//
+// Parameters:
+//   - filepath: file path to write the CSV to (empty string to return as string)
+//   - separator: optional separator for the CSV (defaults to comma)
+//
+// Returns:
+//   - string: CSV representation of the DataFrame if filepath is empty
+//   - error: nil if successful, otherwise an error describing what went wrong
+//
+// Note: If filepath is provided, the method returns ("", nil) on success. Fields are quoted
+// as needed (QuoteMinimal) and null values are written as empty fields; use WriteCSV for the
+// other ToCSVOptions.
+//
+// Example:
+//
+//	// Get CSV as string with default comma separator
+//	csv, err := df.ToCSV("")
+//
+//	// Get CSV as string with custom separator
+//	csv, err := df.ToCSV("", ";")
+//
+//	// Write to file with default comma separator
+//	_, err := df.ToCSV("path/to/output.csv")
+//
+//	// Write to file with custom separator
+//	_, err := df.ToCSV("path/to/output.csv", ";")
+func (df *DataFrame) ToCSV(filepath string, separator ...string) (string, error) {
+	if df == nil {
+		return "", errors.New("DataFrame is nil")
+	}
+
+	opts := ToCSVOptions{}
+	if len(separator) > 0 {
+		opts.Separator = separator[0]
+	}
+
+	var buf bytes.Buffer
+	if err := df.WriteCSV(&buf, opts); err != nil {
+		return "", err
+	}
+
+	// If filepath is provided, write to file and return nil
+	if filepath != "" {
+		err := os.WriteFile(filepath, buf.Bytes(), 0644)
+		if err != nil {
+			return "", fmt.Errorf("failed to write CSV to file: %w", err)
+		}
+		return "", nil
+	}
+
+	// If no filepath, return the CSV string
+	return buf.String(), nil
+}
+
+// WriteCSV writes the DataFrame as CSV to w using the given options.
+//
+// Parameters:
+//   - w: destination of the CSV output, e.g. a file, a network connection or a buffer
+//   - opts: separator, quoting, layout and formatting options
+//
+// Returns:
+//   - error: nil if successful, otherwise an error describing what went wrong. Nothing is
+//     written when the options are invalid; an error from QuoteNone or from w may leave a
+//     partial output.
+//
+// Example:
+//
+//	// Write nulls as NA
+//	err := df.WriteCSV(os.Stdout, ToCSVOptions{NullRep: "NA"})
+//
+//	// Write two columns with CRLF line endings, quoting every text field and rounding floats
+//	err := df.WriteCSV(w, ToCSVOptions{
+//	    Columns:        []string{"name", "price"},
+//	    Quoting:        QuoteNonNumeric,
+//	    LineTerminator: "\r\n",
+//	    FloatFormat:    "%.2f",
+//	})
+func (df *DataFrame) WriteCSV(w io.Writer, opts ToCSVOptions) error {
+	if df == nil {
+		return errors.New("DataFrame is nil")
+	}
+
+	// Default separator is comma
+	sep := opts.Separator
+	if sep == "" {
+		sep = ","
+	}
+	if strings.ContainsAny(sep, "\"\r\n") {
+		return fmt.Errorf("invalid CSV separator %q", sep)
+	}
+	quoting := opts.Quoting
+	switch quoting {
+	case "":
+		quoting = QuoteMinimal
+	case QuoteMinimal, QuoteAll, QuoteNonNumeric, QuoteNone:
+	default:
+		return fmt.Errorf("invalid quoting mode: %s", quoting)
+	}
+	terminator := opts.LineTerminator
+	if terminator == "" {
+		terminator = "\n"
+	}
+
+	// Resolve the columns to write
+	names := df.Columns
+	columns := df.Data
+	if opts.Columns != nil {
+		positions, err := df.columnPositions(opts.Columns)
+		if err != nil {
+			return err
+		}
+		names = opts.Columns
+		columns = make([]Series, len(positions))
+		for i, pos := range positions {
+			columns[i] = df.Data[pos]
+		}
+	}
+	cells := make([]csvCellFunc, len(columns))
+	for i, col := range columns {
+		cells[i] = csvCellFormatter(col, opts.FloatFormat)
+	}
+
+	var index csvCellFunc
+	var labels Series
+	indexLabel := opts.IndexLabel
+	if opts.Index {
+		labels = df.RowIndex().Labels()
+		index = csvCellFormatter(labels, opts.FloatFormat)
+		if indexLabel == "" {
+			indexLabel = df.RowIndex().Name()
+		}
+	}
+
+	fields := len(columns)
+	if opts.Index {
+		fields++
+	}
+	cw := &csvFieldWriter{w: bufio.NewWriter(w), sep: sep, quoting: quoting, single: fields == 1}
+
+	// Write headers
+	if !opts.OmitHeader {
+		if opts.Index {
+			cw.field(indexLabel, false)
+		}
+		for _, name := range names {
+			cw.field(name, false)
+		}
+		cw.endRecord(terminator)
+	}
+
+	// Write data rows, reading each row across the column-major Data
+	numRows := df.rowCount()
+	for r := 0; r < numRows && cw.err == nil; r++ {
+		if opts.Index {
+			if labels.IsNull(r) {
+				cw.null(opts.NullRep)
+			} else {
+				cw.field(index(r))
+			}
+		}
+		for i, col := range columns {
+			if col.IsNull(r) {
+				cw.null(opts.NullRep)
+				continue
+			}
+			cw.field(cells[i](r))
+		}
+		cw.endRecord(terminator)
+	}
+
+	if cw.err != nil {
+		return cw.err
+	}
+	if err := cw.w.Flush(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// csvCellFunc returns the text of the non-null value at row r and whether it is a number.
+type csvCellFunc func(r int) (string, bool)
+
+// csvCellFormatter returns the csvCellFunc for col. Typed columns are read through their
+// backing slices so values are not boxed.
+func csvCellFormatter(col Series, floatFormat string) csvCellFunc {
+	switch c := col.(type) {
+	case *FloatCol:
+		values := c.Values()
+		if floatFormat != "" {
+			return func(r int) (string, bool) { return fmt.Sprintf(floatFormat, values[r]), true }
+		}
+		return func(r int) (string, bool) { return strconv.FormatFloat(values[r], 'g', -1, 64), true }
+	case *IntCol:
+		values := c.Values()
+		return func(r int) (string, bool) { return strconv.FormatInt(values[r], 10), true }
+	case *StringCol:
+		values := c.Values()
+		return func(r int) (string, bool) { return values[r], false }
+	default:
+		return func(r int) (string, bool) {
+			v := col.At(r)
+			switch x := v.(type) {
+			case float64:
+				if floatFormat != "" {
+					return fmt.Sprintf(floatFormat, x), true
+				}
+				return formatValue(x), true
+			case int64:
+				return formatValue(x), true
+			default:
+				return formatValue(v), false
+			}
+		}
+	}
+}
+
+// csvFieldWriter writes the fields of CSV records, quoting them as RFC 4180 requires.
+// The first error is kept in err and turns every later write into a no-op.
+type csvFieldWriter struct {
+	w       *bufio.Writer
+	sep     string
+	quoting QuoteMode
+	single  bool // records hold a single field
+	started bool // a field has been written in the current record
+	err     error
+}
+
+// field writes one field, quoting it according to the quoting mode. numeric reports
+// whether the field holds a number, which QuoteNonNumeric leaves unquoted.
+func (cw *csvFieldWriter) field(text string, numeric bool) {
+	quote := false
+	switch cw.quoting {
+	case QuoteAll:
+		quote = true
+	case QuoteNonNumeric:
+		quote = !numeric || cw.needsQuotes(text)
+	case QuoteNone:
+		if cw.breaksRecord(text) {
+			cw.fail(fmt.Errorf("field %q needs quoting but quoting is %s", text, QuoteNone))
+			return
+		}
+	default:
+		quote = cw.needsQuotes(text)
+	}
+	cw.write(text, quote)
+}
+
+// null writes a null field as rep. Nulls are only quoted under QuoteAll, or when rep
+// itself needs quotes.
+func (cw *csvFieldWriter) null(rep string) {
+	if cw.quoting == QuoteAll {
+		cw.write(rep, true)
+		return
+	}
+	cw.field(rep, true)
+}
+
+// write writes the separator when needed and then text, enclosed in quotes with inner
+// quotes doubled when quote is set.
+func (cw *csvFieldWriter) write(text string, quote bool) {
+	if cw.err != nil {
+		return
+	}
+	if cw.started {
+		cw.w.WriteString(cw.sep)
+	}
+	cw.started = true
+	if !quote {
+		cw.w.WriteString(text)
+		return
+	}
+	cw.w.WriteByte('"')
+	cw.w.WriteString(strings.ReplaceAll(text, `"`, `""`))
+	cw.w.WriteByte('"')
+}
+
+// endRecord terminates the current record.
+func (cw *csvFieldWriter) endRecord(terminator string) {
+	if cw.err != nil {
+		return
+	}
+	cw.w.WriteString(terminator)
+	cw.started = false
+}
+
+// fail records err unless an earlier error is already recorded.
+func (cw *csvFieldWriter) fail(err error) {
+	if cw.err == nil {
+		cw.err = err
+	}
+}
+
+// needsQuotes reports whether text must be quoted to be read back unchanged, following the
+// rules of encoding/csv: fields containing the separator, a quote or a line break, fields
+// starting with whitespace, and the field `\.` (an end-of-data marker in PostgreSQL) are
+// quoted. The empty field is only quoted when it is the whole record, which would
+// otherwise be a blank line that readers skip.
+func (cw *csvFieldWriter) needsQuotes(text string) bool {
+	if text == "" {
+		return cw.single
+	}
+	if text == `\.` {
+		return true
+	}
+	if cw.breaksRecord(text) {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(text)
+	return unicode.IsSpace(r)
+}
+
+// breaksRecord reports whether text, written unquoted, would not read back as a single
+// field because it contains the separator, a quote or a line break, or because it is an
+// empty field making up the whole record.
+func (cw *csvFieldWriter) breaksRecord(text string) bool {
+	if text == "" {
+		return cw.single
+	}
+	return strings.Contains(text, cw.sep) || strings.ContainsAny(text, "\"\r\n")
+}
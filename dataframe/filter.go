@@ -0,0 +1,483 @@
+package dataframe
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Mask is a boolean row selector, one entry per row of a DataFrame or Series. It is
+// produced by the comparison methods of Series (Gt, Eq, ...) and consumed by
+// DataFrame.FilterMask, Where and Mask. Masks hold no nulls: a comparison involving a null
+// value is false.
+type Mask []bool
+
+// NewMask converts a bool Series, such as the result of IsNull or NotNull, into a Mask.
+// Null values become false.
+//
+// Returns:
+//   - Mask: true wherever s holds true
+//   - error: nil if successful, otherwise an error if s is not a bool Series
+func NewMask(s Series) (Mask, error) {
+	col, ok := s.(*BoolCol)
+	if !ok {
+		return nil, fmt.Errorf("mask requires a bool Series, got %s", s.DType())
+	}
+	mask := make(Mask, col.Len())
+	for i, v := range col.Values() {
+		mask[i] = v && !col.IsNull(i)
+	}
+	return mask, nil
+}
+
+// And returns a Mask that is true where both m and other are true. It panics if the masks
+// differ in length, since they cannot then select the same rows.
+//
+// Example:
+//
+//	adults := age.Ge(18).And(age.Lt(65))
+func (m Mask) And(other Mask) Mask {
+	m.checkLength("And", other)
+	out := make(Mask, len(m))
+	for i := range out {
+		out[i] = m[i] && other[i]
+	}
+	return out
+}
+
+// Or returns a Mask that is true where m or other is true. It panics if the masks differ
+// in length.
+func (m Mask) Or(other Mask) Mask {
+	m.checkLength("Or", other)
+	out := make(Mask, len(m))
+	for i := range out {
+		out[i] = m[i] || other[i]
+	}
+	return out
+}
+
+// checkLength panics if other does not have the length of m.
+func (m Mask) checkLength(op string, other Mask) {
+	if len(m) != len(other) {
+		panic(fmt.Sprintf("dataframe: Mask.%s of masks with lengths %d and %d", op, len(m), len(other)))
+	}
+}
+
+// Not returns the inverse of m.
+func (m Mask) Not() Mask {
+	out := make(Mask, len(m))
+	for i, v := range m {
+		out[i] = !v
+	}
+	return out
+}
+
+// Count returns the number of true entries in m.
+func (m Mask) Count() int {
+	n := 0
+	for _, v := range m {
+		if v {
+			n++
+		}
+	}
+	return n
+}
+
+// compareOp is one of the six comparison operators, applied to the result of a
+// three-way comparison.
+type compareOp struct {
+	test      func(c int) bool
+	unordered bool // result for pairs involving NaN, which have no order
+}
+
+var (
+	opGt = compareOp{test: func(c int) bool { return c > 0 }}
+	opGe = compareOp{test: func(c int) bool { return c >= 0 }}
+	opLt = compareOp{test: func(c int) bool { return c < 0 }}
+	opLe = compareOp{test: func(c int) bool { return c <= 0 }}
+	opEq = compareOp{test: func(c int) bool { return c == 0 }}
+	opNe = compareOp{test: func(c int) bool { return c != 0 }, unordered: true}
+)
+
+// apply returns op applied to the three-way comparison c of a pair of values. A pair that
+// is not ordered because it involves NaN satisfies only Ne, as in IEEE 754.
+func (op compareOp) apply(c int, ordered bool) bool {
+	if !ordered {
+		return op.unordered
+	}
+	return op.test(c)
+}
+
+// Gt returns a Mask that is true where the column is greater than v.
+//
+// v is either a scalar, compared with every value, or a Series of the same length,
+// compared position by position. Integers and floats compare numerically with each other;
+// strings, datetimes and bools (false < true) compare only with values of the same type.
+// Positions where either side is null, or where the types cannot be compared, are false.
+// NaN is a value rather than a null but equals nothing: it is false for every comparison
+// except Ne.
+//
+// Example:
+//
+//	age, _ := df.Col("age")
+//	over30 := age.Gt(30)
+func (c *TypeColumn[T]) Gt(v any) Mask { return c.compare(v, opGt) }
+
+// Ge returns a Mask that is true where the column is greater than or equal to v.
+// See Gt for how values are compared.
+func (c *TypeColumn[T]) Ge(v any) Mask { return c.compare(v, opGe) }
+
+// Lt returns a Mask that is true where the column is less than v.
+// See Gt for how values are compared.
+func (c *TypeColumn[T]) Lt(v any) Mask { return c.compare(v, opLt) }
+
+// Le returns a Mask that is true where the column is less than or equal to v.
+// See Gt for how values are compared.
+func (c *TypeColumn[T]) Le(v any) Mask { return c.compare(v, opLe) }
+
+// Eq returns a Mask that is true where the column equals v.
+// See Gt for how values are compared.
+func (c *TypeColumn[T]) Eq(v any) Mask { return c.compare(v, opEq) }
+
+// Ne returns a Mask that is true where the column holds a value different from v.
+// Null positions are false, as for every other comparison. See Gt for how values are
+// compared.
+func (c *TypeColumn[T]) Ne(v any) Mask { return c.compare(v, opNe) }
+
+// compare applies op to every value of the column and v. Scalars compared with float,
+// int and string columns use typed loops over the backing slice; other cases fall back to
+// compareValues on boxed values.
+func (c *TypeColumn[T]) compare(v any, op compareOp) Mask {
+	mask := make(Mask, len(c.data))
+	if other, ok := v.(Series); ok {
+		for i := range mask {
+			if i < other.Len() && !c.IsNull(i) && !other.IsNull(i) {
+				a, b := any(c.data[i]), other.At(i)
+				if r, ok := compareValues(a, b); ok {
+					mask[i] = op.apply(r, !isNaN(a) && !isNaN(b))
+				}
+			}
+		}
+		return mask
+	}
+
+	v = normalizeValue(v)
+	if v == nil {
+		return mask
+	}
+	switch data := any(c.data).(type) {
+	case []float64:
+		switch x := v.(type) {
+		case float64:
+			compareSlice(mask, data, x, op)
+		case int64:
+			compareSlice(mask, data, float64(x), op)
+		}
+	case []int64:
+		switch x := v.(type) {
+		case int64:
+			compareSlice(mask, data, x, op)
+		case float64:
+			for i, val := range data {
+				mask[i] = op.apply(cmp.Compare(float64(val), x), !math.IsNaN(x))
+			}
+		}
+	case []string:
+		if x, ok := v.(string); ok {
+			compareSlice(mask, data, x, op)
+		}
+	default:
+		for i, val := range c.data {
+			if r, ok := compareValues(val, v); ok {
+				mask[i] = op.apply(r, !isNaN(v))
+			}
+		}
+	}
+
+	// Comparisons with null values are false
+	if c.valid != nil {
+		for i := range mask {
+			if !c.valid.get(i) {
+				mask[i] = false
+			}
+		}
+	}
+	return mask
+}
+
+// compareSlice sets mask[i] to op applied to the comparison of data[i] with v. Only NaN
+// is unequal to itself, so the self-comparisons find the unordered pairs.
+func compareSlice[T cmp.Ordered](mask Mask, data []T, v T, op compareOp) {
+	for i, val := range data {
+		mask[i] = op.apply(cmp.Compare(val, v), val == val && v == v)
+	}
+}
+
+// isNaN reports whether v is a float NaN.
+func isNaN(v any) bool {
+	switch x := v.(type) {
+	case float64:
+		return math.IsNaN(x)
+	case float32:
+		return math.IsNaN(float64(x))
+	}
+	return false
+}
+
+// compareValues compares two non-null boxed values and returns -1, 0 or +1, or false if
+// they cannot be compared. Integers and floats compare numerically with each other.
+func compareValues(a, b any) (int, bool) {
+	a, b = normalizeValue(a), normalizeValue(b)
+	switch x := a.(type) {
+	case float64:
+		switch y := b.(type) {
+		case float64:
+			return cmp.Compare(x, y), true
+		case int64:
+			return cmp.Compare(x, float64(y)), true
+		}
+	case int64:
+		switch y := b.(type) {
+		case int64:
+			return cmp.Compare(x, y), true
+		case float64:
+			return cmp.Compare(float64(x), y), true
+		}
+	case string:
+		if y, ok := b.(string); ok {
+			return cmp.Compare(x, y), true
+		}
+	case time.Time:
+		if y, ok := b.(time.Time); ok {
+			return x.Compare(y), true
+		}
+	case bool:
+		if y, ok := b.(bool); ok {
+			switch {
+			case x == y:
+				return 0, true
+			case y:
+				return -1, true
+			default:
+				return 1, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// columnComparator returns a three-way comparison of the values at two positions of col.
+// Float, int, string, datetime and bool columns compare their backing slices directly;
+// other columns fall back to compareValues, treating values that cannot be compared as
+// equal. Null positions are not handled and must be filtered out by the caller.
+func columnComparator(col Series) func(i, j int) int {
+	switch c := col.(type) {
+	case *FloatCol:
+		return func(i, j int) int { return cmp.Compare(c.data[i], c.data[j]) }
+	case *IntCol:
+		return func(i, j int) int { return cmp.Compare(c.data[i], c.data[j]) }
+	case *StringCol:
+		return func(i, j int) int { return cmp.Compare(c.data[i], c.data[j]) }
+	case *DateTimeCol:
+		return func(i, j int) int { return c.data[i].Compare(c.data[j]) }
+	case *BoolCol:
+		return func(i, j int) int {
+			// false < true
+			switch x, y := c.data[i], c.data[j]; {
+			case x == y:
+				return 0
+			case y:
+				return -1
+			default:
+				return 1
+			}
+		}
+	default:
+		return func(i, j int) int {
+			r, _ := compareValues(col.At(i), col.At(j))
+			return r
+		}
+	}
+}
+
+// Row is a read-only view of one row of a DataFrame, passed to the predicate of Filter.
+type Row struct {
+	df        *DataFrame
+	positions map[string]int
+	index     int
+}
+
+// Index returns the position of the row in the DataFrame.
+func (r Row) Index() int {
+	return r.index
+}
+
+// Label returns the index label of the row.
+func (r Row) Label() any {
+	return r.df.RowIndex().Label(r.index)
+}
+
+// Get returns the value of the named column in this row, or nil if the value is null or
+// the DataFrame has no such column.
+func (r Row) Get(col string) any {
+	pos, ok := r.positions[col]
+	if !ok {
+		return nil
+	}
+	return r.df.Data[pos].At(r.index)
+}
+
+// IsNull reports whether the value of the named column in this row is null. A column
+// that does not exist counts as null.
+func (r Row) IsNull(col string) bool {
+	pos, ok := r.positions[col]
+	return !ok || r.df.Data[pos].IsNull(r.index)
+}
+
+// Filter returns a new DataFrame holding the rows for which pred returns true, in their
+// original order, with their index labels.
+//
+// Filter calls pred once per row with a Row view. It is the most flexible way to filter
+// but boxes every value it reads; comparisons on whole columns combined into a Mask and
+// passed to FilterMask are much faster on large frames.
+//
+// Parameters:
+//   - pred: the row predicate. It must not modify the DataFrame.
+//
+// Returns:
+//   - A new DataFrame with the matching rows.
+//   - An error if the DataFrame is nil.
+//
+// Example:
+//
+//	adults, err := df.Filter(func(row Row) bool {
+//	    age, ok := row.Get("age").(int64)
+//	    return ok && age >= 18
+//	})
+func (df *DataFrame) Filter(pred func(row Row) bool) (*DataFrame, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+
+	positions := make(map[string]int, len(df.Columns))
+	for i := len(df.Columns) - 1; i >= 0; i-- {
+		positions[df.Columns[i]] = i
+	}
+
+	mask := make(Mask, df.rowCount())
+	for i := range mask {
+		mask[i] = pred(Row{df: df, positions: positions, index: i})
+	}
+	return df.FilterMask(mask)
+}
+
+// FilterMask returns a new DataFrame holding the rows where mask is true, in their
+// original order. The result keeps the index labels of the selected rows; use ResetIndex
+// to renumber them.
+//
+// Parameters:
+//   - mask: one entry per row, usually built from column comparisons
+//
+// Returns:
+//   - A new DataFrame with the selected rows.
+//   - An error if the DataFrame is nil or the mask length differs from the row count.
+//
+// Example:
+//
+//	age, _ := df.Col("age")
+//	city, _ := df.Col("city")
+//	result, err := df.FilterMask(age.Gt(30).And(city.Eq("Paris").Not()))
+func (df *DataFrame) FilterMask(mask Mask) (*DataFrame, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+	if err := df.checkMask(mask); err != nil {
+		return nil, err
+	}
+
+	rows := make([]int, 0, mask.Count())
+	for i, keep := range mask {
+		if keep {
+			rows = append(rows, i)
+		}
+	}
+	return df.takeRows(rows), nil
+}
+
+// Where returns a new DataFrame of the same shape that keeps the values of the rows where
+// mask is true and replaces every value of the other rows with null.
+//
+// Returns:
+//   - A new DataFrame with the same columns and row count as df.
+//   - An error if the DataFrame is nil or the mask length differs from the row count.
+//
+// Example:
+//
+//	age, _ := df.Col("age")
+//	result, err := df.Where(age.Ge(18))
+//	// rows with age < 18 or a null age are all null
+func (df *DataFrame) Where(mask Mask) (*DataFrame, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+	if err := df.checkMask(mask); err != nil {
+		return nil, err
+	}
+	return df.nullRows(mask, false), nil
+}
+
+// Mask is the inverse of Where: it returns a new DataFrame of the same shape that replaces
+// every value of the rows where mask is true with null and keeps the other rows.
+//
+// Returns:
+//   - A new DataFrame with the same columns and row count as df.
+//   - An error if the DataFrame is nil or the mask length differs from the row count.
+func (df *DataFrame) Mask(mask Mask) (*DataFrame, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+	if err := df.checkMask(mask); err != nil {
+		return nil, err
+	}
+	return df.nullRows(mask, true), nil
+}
+
+// checkMask returns an error if mask does not have one entry per row.
+func (df *DataFrame) checkMask(mask Mask) error {
+	if len(mask) != df.rowCount() {
+		return fmt.Errorf("mask length %d does not match row count %d", len(mask), df.rowCount())
+	}
+	return nil
+}
+
+// nullRows returns a copy of df in which every row whose mask entry equals nullWhen is
+// null. The index is kept unchanged.
+func (df *DataFrame) nullRows(mask Mask, nullWhen bool) *DataFrame {
+	rows := make([]int, len(mask))
+	for i, v := range mask {
+		if v == nullWhen {
+			rows[i] = -1
+		} else {
+			rows[i] = i
+		}
+	}
+	out := df.takeRows(rows)
+	out.Index = df.Index
+	return out
+}
+
+// takeRows returns a new DataFrame holding the given rows of every column, in order, with
+// the index labels of those rows. A negative row produces nulls.
+func (df *DataFrame) takeRows(rows []int) *DataFrame {
+	out := &DataFrame{
+		Columns: append([]string(nil), df.Columns...),
+		Data:    make([]Series, len(df.Data)),
+		Index:   df.RowIndex().Take(rows),
+	}
+	for i, col := range df.Data {
+		out.Data[i] = col.Take(rows)
+	}
+	return out
+}
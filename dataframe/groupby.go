@@ -0,0 +1,538 @@
+package dataframe
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// groupByParallelThreshold is the row count from which key columns are factorized in
+// parallel chunks rather than in a single pass.
+const groupByParallelThreshold = 1 << 16
+
+// GroupedFrame is a DataFrame split into groups of rows sharing the same values in the key
+// columns. It is created by DataFrame.GroupBy and combines the groups back into a
+// DataFrame through its aggregation methods, Agg, Aggregate, Apply, Transform and Filter.
+//
+// Groups are ordered by their key values, in ascending order. Rows with a null or NaN value
+// in any key column belong to no group.
+type GroupedFrame struct {
+	frame  *DataFrame // snapshot of the grouped DataFrame
+	keys   []string
+	keyPos []int
+	groups [][]int // ascending row positions of each group
+}
+
+// GroupBy splits the DataFrame into groups of rows that share the same values in the
+// given key columns.
+//
+// Each key column is factorized into integer codes with a hash table; on large frames
+// the rows are factorized in parallel chunks and every key column runs in its own
+// goroutine. The codes of several keys are then combined into one group id per row.
+//
+// The GroupedFrame keeps a snapshot of the columns of df, so later changes to the column
+// list of df do not affect it.
+//
+// Parameters:
+//   - keys: names of the columns to group by. Every name must be present in the DataFrame
+//     and may appear only once.
+//
+// Returns:
+//   - A GroupedFrame ready to aggregate.
+//   - An error if no key is given, a key is not present or a key is repeated, or if a key
+//     column holds values that cannot be hashed, such as slices.
+//
+// Example:
+//
+//	// df has columns city, product, sales
+//	grouped, err := df.GroupBy("city")
+//	totals, err := grouped.Sum()
+//	// city   | sales
+//	// Berlin | 310
+//	// Paris  | 520
+func (df *DataFrame) GroupBy(keys ...string) (*GroupedFrame, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("at least one group key is required")
+	}
+
+	df.Lock()
+	defer df.Unlock()
+
+	if err := checkUniqueColumns(keys); err != nil {
+		return nil, err
+	}
+	positions, err := df.columnPositions(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	frame := &DataFrame{
+		Columns: append([]string(nil), df.Columns...),
+		Data:    append([]Series(nil), df.Data...),
+		Index:   df.Index,
+	}
+	keyCols := make([]Series, len(positions))
+	for i, pos := range positions {
+		keyCols[i] = frame.Data[pos]
+		if err := checkHashable(keyCols[i]); err != nil {
+			return nil, fmt.Errorf("cannot group by %s: %w", keys[i], err)
+		}
+	}
+	return &GroupedFrame{
+		frame:  frame,
+		keys:   append([]string(nil), keys...),
+		keyPos: positions,
+		groups: groupRows(keyCols),
+	}, nil
+}
+
+// NGroups returns the number of groups.
+func (g *GroupedFrame) NGroups() int {
+	return len(g.groups)
+}
+
+// Sum returns the sum of every int and float column per group. Other columns are left out.
+// See AggSum.
+func (g *GroupedFrame) Sum() (*DataFrame, error) { return g.Aggregate(AggSum) }
+
+// Mean returns the mean of every int and float column per group. Other columns are left
+// out. See AggMean.
+func (g *GroupedFrame) Mean() (*DataFrame, error) { return g.Aggregate(AggMean) }
+
+// Min returns the smallest value of every column per group. See AggMin.
+func (g *GroupedFrame) Min() (*DataFrame, error) { return g.Aggregate(AggMin) }
+
+// Max returns the largest value of every column per group. See AggMax.
+func (g *GroupedFrame) Max() (*DataFrame, error) { return g.Aggregate(AggMax) }
+
+// Count returns the number of non-null values of every column per group. See AggCount.
+func (g *GroupedFrame) Count() (*DataFrame, error) { return g.Aggregate(AggCount) }
+
+// Std returns the sample standard deviation of every int and float column per group.
+// Other columns are left out. See AggStd.
+func (g *GroupedFrame) Std() (*DataFrame, error) { return g.Aggregate(AggStd) }
+
+// Var returns the sample variance of every int and float column per group. Other columns
+// are left out. See AggVar.
+func (g *GroupedFrame) Var() (*DataFrame, error) { return g.Aggregate(AggVar) }
+
+// First returns the first non-null value of every column per group. See AggFirst.
+func (g *GroupedFrame) First() (*DataFrame, error) { return g.Aggregate(AggFirst) }
+
+// Last returns the last non-null value of every column per group. See AggLast.
+func (g *GroupedFrame) Last() (*DataFrame, error) { return g.Aggregate(AggLast) }
+
+// NUnique returns the number of distinct non-null values of every column per group.
+// See AggNUnique.
+func (g *GroupedFrame) NUnique() (*DataFrame, error) { return g.Aggregate(AggNUnique) }
+
+// Median returns the median of every int and float column per group. Other columns are
+// left out. See AggMedian.
+func (g *GroupedFrame) Median() (*DataFrame, error) { return g.Aggregate(AggMedian) }
+
+// Aggregate applies fn to every non-key column and returns one row per group: the key
+// columns followed by the aggregated columns, which keep their names. Aggregations that
+// need numbers (sum, mean, std, var, median) skip columns of other dtypes.
+//
+// Columns are aggregated concurrently.
+//
+// Example:
+//
+//	grouped, _ := df.GroupBy("city")
+//	result, err := grouped.Aggregate(AggMax)
+//	// city | product | sales
+func (g *GroupedFrame) Aggregate(fn AggFunc) (*DataFrame, error) {
+	var specs []aggSpec
+	for pos, name := range g.frame.Columns {
+		if slices.Contains(g.keyPos, pos) {
+			continue
+		}
+		if fn.numeric && !isNumeric(g.frame.Data[pos]) {
+			continue
+		}
+		specs = append(specs, aggSpec{name: name, pos: pos, fn: fn})
+	}
+	return g.aggregate(specs)
+}
+
+// Agg applies several named aggregations and returns one row per group: the key columns
+// followed by one column per aggregation, named "<column>_<aggregation>".
+//
+// Result columns follow the order of the columns in the DataFrame and, within a column,
+// the order of its aggregations. Columns are aggregated concurrently.
+//
+// Parameters:
+//   - aggs: the aggregations to apply to each column, keyed by column name
+//
+// Returns:
+//   - A new DataFrame with one row per group.
+//   - An error if a column is not present, an aggregation needs numbers but the column holds
+//     another dtype, or two aggregations would produce the same column name.
+//
+// Example:
+//
+//	stats, err := grouped.Agg(map[string][]AggFunc{
+//	    "product": {AggNUnique},
+//	    "sales":   {AggSum, AggMean},
+//	})
+//	// city | product_nunique | sales_sum | sales_mean
+func (g *GroupedFrame) Agg(aggs map[string][]AggFunc) (*DataFrame, error) {
+	if len(aggs) == 0 {
+		return nil, errors.New("at least one aggregation is required")
+	}
+	cols := make([]string, 0, len(aggs))
+	for col := range aggs {
+		cols = append(cols, col)
+	}
+	slices.Sort(cols)
+	if _, err := g.frame.columnPositions(cols); err != nil {
+		return nil, err
+	}
+
+	var specs []aggSpec
+	for pos, col := range g.frame.Columns {
+		for _, fn := range aggs[col] {
+			specs = append(specs, aggSpec{name: col + "_" + fn.Name, pos: pos, fn: fn})
+		}
+	}
+	return g.aggregate(specs)
+}
+
+// aggSpec is one result column of an aggregation: fn applied to the column at pos.
+type aggSpec struct {
+	name string
+	pos  int
+	fn   AggFunc
+}
+
+// aggregate runs specs concurrently and assembles the key columns and the results into
+// one row per group.
+func (g *GroupedFrame) aggregate(specs []aggSpec) (*DataFrame, error) {
+	out := g.keyFrame()
+	results := make([]Series, len(specs))
+	errs := make([]error, len(specs))
+	var wg sync.WaitGroup
+	for i, spec := range specs {
+		wg.Add(1)
+		go func(i int, spec aggSpec) {
+			defer wg.Done()
+			col := g.frame.Data[spec.pos]
+			series, err := spec.fn.apply(col, g.groups)
+			if err != nil {
+				errs[i] = fmt.Errorf("column %s: %w", g.frame.Columns[spec.pos], err)
+				return
+			}
+			results[i] = series
+		}(i, spec)
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	for i, spec := range specs {
+		out.Columns = append(out.Columns, spec.name)
+		out.Data = append(out.Data, results[i])
+	}
+	if err := checkUniqueColumns(out.Columns); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// keyFrame returns a DataFrame holding the key values of every group, one row per group.
+func (g *GroupedFrame) keyFrame() *DataFrame {
+	firstRows := make([]int, len(g.groups))
+	for i, rows := range g.groups {
+		firstRows[i] = rows[0]
+	}
+	out := &DataFrame{}
+	for i, pos := range g.keyPos {
+		out.Columns = append(out.Columns, g.keys[i])
+		out.Data = append(out.Data, g.frame.Data[pos].Take(firstRows))
+	}
+	return out
+}
+
+// Apply calls fn with each group as a DataFrame, in group order, and stacks the returned
+// DataFrames vertically. Groups keep the index labels of their rows, and so do the stacked
+// results unless fn replaces them.
+//
+// Parameters:
+//   - fn: called once per group. It may return a DataFrame of any length, or nil to leave
+//     the group out. Every returned DataFrame must have the same columns.
+//
+// Returns:
+//   - The stacked results, or an empty DataFrame if fn returned nil for every group.
+//   - An error if fn fails or returns DataFrames with different columns.
+//
+// Example:
+//
+//	// keep the first two rows of each city
+//	head, err := grouped.Apply(func(group *DataFrame) (*DataFrame, error) {
+//	    return group.Iloc(Range(0, 2), All())
+//	})
+func (g *GroupedFrame) Apply(fn func(group *DataFrame) (*DataFrame, error)) (*DataFrame, error) {
+	var results []*DataFrame
+	for i, rows := range g.groups {
+		result, err := fn(g.frame.takeRows(rows))
+		if err != nil {
+			return nil, fmt.Errorf("group %d: %w", i, err)
+		}
+		if result != nil {
+			results = append(results, result)
+		}
+	}
+	if len(results) == 0 {
+		return &DataFrame{}, nil
+	}
+	return concatRows(results)
+}
+
+// Transform calls fn with the values of each group for every non-key column and returns a
+// DataFrame of the same shape and index as the original, holding the transformed values
+// in the original row order. Rows that belong to no group are null.
+//
+// Parameters:
+//   - fn: called once per group and column. It must return a Series of the same length as
+//     its input.
+//
+// Returns:
+//   - A new DataFrame with the non-key columns and one row per original row.
+//   - An error if fn fails or returns a Series of a different length.
+//
+// Example:
+//
+//	// replace every value with the first value of its group
+//	firsts, err := grouped.Transform(func(values Series) (Series, error) {
+//	    return values.Take(make([]int, values.Len())), nil
+//	})
+func (g *GroupedFrame) Transform(fn func(values Series) (Series, error)) (*DataFrame, error) {
+	rowCount := g.frame.rowCount()
+	order := make([]int, 0, rowCount)
+	for _, rows := range g.groups {
+		order = append(order, rows...)
+	}
+	// scatter maps every original row to its position in the stacked group results
+	scatter := make([]int, rowCount)
+	for i := range scatter {
+		scatter[i] = -1
+	}
+	for i, r := range order {
+		scatter[r] = i
+	}
+
+	out := &DataFrame{Index: g.frame.Index}
+	for pos, name := range g.frame.Columns {
+		if slices.Contains(g.keyPos, pos) {
+			continue
+		}
+		col := g.frame.Data[pos]
+		parts := make([]Series, len(g.groups))
+		for i, rows := range g.groups {
+			result, err := fn(col.Take(rows))
+			if err != nil {
+				return nil, fmt.Errorf("column %s, group %d: %w", name, i, err)
+			}
+			if result == nil || result.Len() != len(rows) {
+				return nil, fmt.Errorf("column %s, group %d: transform must return %d values", name, i, len(rows))
+			}
+			parts[i] = result
+		}
+		out.Columns = append(out.Columns, name)
+		out.Data = append(out.Data, concatSeries(parts).Take(scatter))
+	}
+	return out, nil
+}
+
+// Filter returns the rows of the groups for which pred returns true, in their original
+// order and with their index labels.
+//
+// Example:
+//
+//	// keep the cities with at least three rows
+//	busy, err := grouped.Filter(func(group *DataFrame) bool {
+//	    return group.RowIndex().Len() >= 3
+//	})
+func (g *GroupedFrame) Filter(pred func(group *DataFrame) bool) (*DataFrame, error) {
+	var rows []int
+	for _, group := range g.groups {
+		if pred(g.frame.takeRows(group)) {
+			rows = append(rows, group...)
+		}
+	}
+	slices.Sort(rows)
+	return g.frame.takeRows(rows), nil
+}
+
+// groupRows returns the row positions of every group of equal key values, with the groups
+// sorted by their keys. Key columns are factorized concurrently and their codes combined
+// into one group id per row; rows with a null key are left out.
+func groupRows(keyCols []Series) [][]int {
+	codes := make([][]int, len(keyCols))
+	cardinalities := make([]int, len(keyCols))
+	var wg sync.WaitGroup
+	for i, col := range keyCols {
+		wg.Add(1)
+		go func(i int, col Series) {
+			defer wg.Done()
+			codes[i], cardinalities[i] = factorize(col)
+		}(i, col)
+	}
+	wg.Wait()
+
+	ids, count := combineCodes(codes, cardinalities)
+	groups := make([][]int, count)
+	for r, id := range ids {
+		if id >= 0 {
+			groups[id] = append(groups[id], r)
+		}
+	}
+
+	compares := make([]func(i, j int) int, len(keyCols))
+	for i, col := range keyCols {
+		compares[i] = columnComparator(col)
+	}
+	slices.SortStableFunc(groups, func(a, b []int) int {
+		for _, compare := range compares {
+			if c := compare(a[0], b[0]); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return groups
+}
+
+// combineCodes folds the codes of several key columns into one dense group id per row,
+// numbered in order of first appearance. Columns are combined pairwise and re-densified
+// after each step, so the mixed-radix key never exceeds rows × cardinality.
+// A row with a null code in any column gets -1.
+func combineCodes(codes [][]int, cardinalities []int) ([]int, int) {
+	ids, count := codes[0], cardinalities[0]
+	for k := 1; k < len(codes); k++ {
+		next := codes[k]
+		seen := make(map[int]int)
+		combined := make([]int, len(ids))
+		for r, id := range ids {
+			if id < 0 || next[r] < 0 {
+				combined[r] = -1
+				continue
+			}
+			key := id*cardinalities[k] + next[r]
+			group, ok := seen[key]
+			if !ok {
+				group = len(seen)
+				seen[key] = group
+			}
+			combined[r] = group
+		}
+		ids, count = combined, len(seen)
+	}
+	return ids, count
+}
+
+// factorizer is implemented by every TypeColumn; see TypeColumn.factorize.
+type factorizer interface {
+	factorize() ([]int, int)
+}
+
+// factorize returns a dense integer code per value of col, numbered in order of first
+// appearance, together with the number of distinct values. Null values get -1, and so do
+// NaN values, which equal nothing and would otherwise each get a code of their own.
+func factorize(col Series) ([]int, int) {
+	if f, ok := col.(factorizer); ok {
+		return f.factorize()
+	}
+	values := make([]any, col.Len())
+	for i := range values {
+		values[i] = col.At(i)
+	}
+	boxed, _ := NewSeriesAs(ObjectType, values)
+	return boxed.(factorizer).factorize()
+}
+
+// factorize hashes the values of the column into dense codes. Columns of at least
+// groupByParallelThreshold rows are split into one chunk per usable CPU; each chunk is factorized
+// with its own hash table, then the chunk tables are merged in order so that codes still
+// follow the first appearance of each value in the whole column.
+func (c *TypeColumn[T]) factorize() ([]int, int) {
+	n := len(c.data)
+	codes := make([]int, n)
+	if n < groupByParallelThreshold {
+		return codes, len(c.factorizeRange(codes, 0, n))
+	}
+
+	chunks := runtime.GOMAXPROCS(0)
+	size := (n + chunks - 1) / chunks
+	uniques := make([][]T, 0, chunks)
+	for start := 0; start < n; start += size {
+		uniques = append(uniques, nil)
+	}
+	var wg sync.WaitGroup
+	for i := range uniques {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			start := i * size
+			uniques[i] = c.factorizeRange(codes, start, min(start+size, n))
+		}(i)
+	}
+	wg.Wait()
+
+	global := make(map[T]int)
+	remaps := make([][]int, len(uniques))
+	for i, values := range uniques {
+		remaps[i] = make([]int, len(values))
+		for j, v := range values {
+			code, ok := global[v]
+			if !ok {
+				code = len(global)
+				global[v] = code
+			}
+			remaps[i][j] = code
+		}
+	}
+
+	for i := range uniques {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			start := i * size
+			for r := start; r < min(start+size, n); r++ {
+				if codes[r] >= 0 {
+					codes[r] = remaps[i][codes[r]]
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	return codes, len(global)
+}
+
+// factorizeRange writes codes for the rows in [start, end), local to that range, and
+// returns the distinct values of the range in order of first appearance.
+func (c *TypeColumn[T]) factorizeRange(codes []int, start, end int) []T {
+	seen := make(map[T]int)
+	var uniques []T
+	for r := start; r < end; r++ {
+		v := c.data[r]
+		if c.IsNull(r) || v != v {
+			codes[r] = -1
+			continue
+		}
+		code, ok := seen[v]
+		if !ok {
+			code = len(uniques)
+			seen[v] = code
+			uniques = append(uniques, v)
+		}
+		codes[r] = code
+	}
+	return uniques
+}
@@ -0,0 +1,523 @@
+package dataframe
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Index holds the row labels of a DataFrame.
+//
+// A DataFrame whose Index field is nil uses a RangeIndex labelling its rows 0, 1, 2, ...
+// SetIndex replaces it with a LabelIndex built from a column, and ResetIndex turns the
+// labels back into a column. Operations that select rows (Filter, FilterMask, Iloc, Loc)
+// carry the labels of the selected rows over to the result, while operations that keep
+// every row (Select, Drop, Where, Mask) keep the index unchanged.
+//
+// Index implementations are immutable, so DataFrames may share them.
+type Index interface {
+	// Len returns the number of labels.
+	Len() int
+	// Name returns the name of the index, or "" for an unnamed index.
+	Name() string
+	// Label returns the label of row i, or nil if it is null.
+	Label(i int) any
+	// Labels returns the labels as a Series.
+	Labels() Series
+	// Positions returns the positions of the rows labelled label, in order, or nil if no
+	// row has that label. Integer labels of any width match.
+	Positions(label any) []int
+	// Take returns a new Index holding the labels at the given positions, in order.
+	// A negative position produces a null label.
+	Take(positions []int) Index
+}
+
+// RangeIndex labels rows with the consecutive integers Start, Start+1, ..., Stop-1.
+// It is the default index of a DataFrame and stores no labels.
+type RangeIndex struct {
+	Start int
+	Stop  int
+}
+
+// NewRangeIndex returns the RangeIndex labelling n rows 0, 1, ..., n-1.
+func NewRangeIndex(n int) RangeIndex {
+	return RangeIndex{Start: 0, Stop: n}
+}
+
+// Len returns the number of labels.
+func (r RangeIndex) Len() int {
+	return r.Stop - r.Start
+}
+
+// Name returns "", as a RangeIndex is always unnamed.
+func (r RangeIndex) Name() string {
+	return ""
+}
+
+// Label returns the label of row i as an int64.
+func (r RangeIndex) Label(i int) any {
+	return int64(r.Start + i)
+}
+
+// Labels returns the labels as an IntCol.
+func (r RangeIndex) Labels() Series {
+	labels := make([]int64, r.Len())
+	for i := range labels {
+		labels[i] = int64(r.Start + i)
+	}
+	return NewIntCol(labels)
+}
+
+// Positions returns the position of the row labelled label, or nil if label is not an
+// integer within the range.
+func (r RangeIndex) Positions(label any) []int {
+	n, ok := normalizeValue(label).(int64)
+	if !ok || n < int64(r.Start) || n >= int64(r.Stop) {
+		return nil
+	}
+	return []int{int(n) - r.Start}
+}
+
+// Take returns the labels at the given positions. Consecutive ascending positions give
+// another RangeIndex; anything else gives an unnamed LabelIndex of int64 labels.
+func (r RangeIndex) Take(positions []int) Index {
+	contiguous := true
+	for i, pos := range positions {
+		if pos < 0 || (i > 0 && pos != positions[i-1]+1) {
+			contiguous = false
+			break
+		}
+	}
+	if contiguous {
+		if len(positions) == 0 {
+			return RangeIndex{}
+		}
+		return RangeIndex{Start: r.Start + positions[0], Stop: r.Start + positions[0] + len(positions)}
+	}
+	return NewLabelIndex("", r.Labels().Take(positions))
+}
+
+// LabelIndex labels rows with the values of a Series, such as a column moved into the
+// index by SetIndex. Labels need not be unique.
+type LabelIndex struct {
+	name   string
+	labels Series
+
+	once   sync.Once
+	lookup map[any][]int // label -> positions, built on first use
+}
+
+// NewLabelIndex creates a LabelIndex named name that takes ownership of labels.
+func NewLabelIndex(name string, labels Series) *LabelIndex {
+	return &LabelIndex{name: name, labels: labels}
+}
+
+// Len returns the number of labels.
+func (l *LabelIndex) Len() int {
+	return l.labels.Len()
+}
+
+// Name returns the name of the index.
+func (l *LabelIndex) Name() string {
+	return l.name
+}
+
+// Label returns the label of row i, or nil if it is null.
+func (l *LabelIndex) Label(i int) any {
+	return l.labels.At(i)
+}
+
+// Labels returns the labels. The Series is shared with the index and must not be modified.
+func (l *LabelIndex) Labels() Series {
+	return l.labels
+}
+
+// Positions returns the positions of the rows labelled label. Null labels never match,
+// and neither do labels that cannot be hashed, such as slices. The label lookup table is
+// built on the first call.
+func (l *LabelIndex) Positions(label any) []int {
+	l.once.Do(func() {
+		l.lookup = make(map[any][]int, l.labels.Len())
+		for i := 0; i < l.labels.Len(); i++ {
+			if v := l.labels.At(i); v != nil && hashable(v) {
+				l.lookup[v] = append(l.lookup[v], i)
+			}
+		}
+	})
+	label = normalizeValue(label)
+	if !hashable(label) {
+		return nil
+	}
+	return l.lookup[label]
+}
+
+// Take returns a LabelIndex with the same name holding the labels at the given positions.
+func (l *LabelIndex) Take(positions []int) Index {
+	return NewLabelIndex(l.name, l.labels.Take(positions))
+}
+
+// RowIndex returns the index of the DataFrame: its Index field, or a RangeIndex over the
+// rows when the field is nil.
+func (df *DataFrame) RowIndex() Index {
+	if df.Index != nil {
+		return df.Index
+	}
+	return NewRangeIndex(df.rowCount())
+}
+
+// SetIndex returns a new DataFrame whose index holds the values of the named column. The
+// column is removed from the data columns.
+//
+// Parameters:
+//   - col: the name of the column to use as the index
+//
+// Returns:
+//   - A new DataFrame indexed by col, with copies of the remaining columns.
+//   - An error if the DataFrame is nil, the column is not present or it holds values that
+//     cannot be hashed, such as slices.
+//
+// Example:
+//
+//	// df has columns id, name, age
+//	byID, err := df.SetIndex("id")
+//	alice, err := byID.Loc(Labels(int64(1)), nil)
+func (df *DataFrame) SetIndex(col string) (*DataFrame, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+
+	df.Lock()
+	defer df.Unlock()
+
+	positions, err := df.columnPositions([]string{col})
+	if err != nil {
+		return nil, err
+	}
+	keep := make([]int, 0, len(df.Columns)-1)
+	for i := range df.Columns {
+		if i != positions[0] {
+			keep = append(keep, i)
+		}
+	}
+	if err := checkHashable(df.Data[positions[0]]); err != nil {
+		return nil, fmt.Errorf("cannot index by column '%s': %w", col, err)
+	}
+	out := df.takeColumns(keep)
+	out.Index = NewLabelIndex(col, df.Data[positions[0]].Copy())
+	return out, nil
+}
+
+// ResetIndex returns a new DataFrame with the default RangeIndex.
+//
+// Parameters:
+//   - drop: discard the current labels instead of inserting them as the first column. The
+//     column is named after the index, or "index" if the index is unnamed.
+//
+// Returns:
+//   - A new DataFrame with copies of the columns and a RangeIndex.
+//   - An error if the DataFrame is nil or the inserted column would duplicate a column name.
+//
+// Example:
+//
+//	filtered, _ := df.FilterMask(mask) // keeps the labels of the matching rows
+//	renumbered, err := filtered.ResetIndex(true)
+func (df *DataFrame) ResetIndex(drop bool) (*DataFrame, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+
+	df.Lock()
+	defer df.Unlock()
+
+	positions := make([]int, len(df.Columns))
+	for i := range positions {
+		positions[i] = i
+	}
+	out := df.takeColumns(positions)
+	out.Index = nil
+	if drop {
+		return out, nil
+	}
+
+	index := df.RowIndex()
+	name := index.Name()
+	if name == "" {
+		name = "index"
+	}
+	for _, col := range df.Columns {
+		if col == name {
+			return nil, fmt.Errorf("cannot insert index as column '%s': column already exists", name)
+		}
+	}
+	out.Columns = append([]string{name}, out.Columns...)
+	out.Data = append([]Series{index.Labels().Copy()}, out.Data...)
+	return out, nil
+}
+
+// axis describes the rows or columns of a DataFrame for a Selector.
+type axis struct {
+	name    string // "row" or "column", for error messages
+	length  int
+	byLabel bool // resolving for Loc rather than Iloc
+	lookup  func(label any) []int
+}
+
+// find returns the positions of label along the axis, or an error if the label cannot be
+// hashed or is not present.
+func (a axis) find(label any) ([]int, error) {
+	if !hashable(label) {
+		return nil, fmt.Errorf("%s label %v has the unhashable type %T", a.name, label, label)
+	}
+	found := a.lookup(label)
+	if len(found) == 0 {
+		return nil, fmt.Errorf("%s label %v not found", a.name, label)
+	}
+	return found, nil
+}
+
+// Selector picks positions along the rows or columns of a DataFrame for Iloc and Loc.
+//
+// Positional selectors (Range, Positions) are accepted by Iloc, label selectors (Labels,
+// LabelRange) by Loc, and All and Mask by both. A nil Selector selects everything.
+type Selector interface {
+	positions(a axis) ([]int, error)
+}
+
+type allSelector struct{}
+
+// All returns a Selector that selects every row or column.
+func All() Selector {
+	return allSelector{}
+}
+
+func (allSelector) positions(a axis) ([]int, error) {
+	out := make([]int, a.length)
+	for i := range out {
+		out[i] = i
+	}
+	return out, nil
+}
+
+type rangeSelector struct {
+	start, stop int
+}
+
+// Range returns a positional Selector for the half-open range [start, stop). As in Python
+// slicing, negative bounds count from the end and bounds beyond either end are clamped, so
+// Range(-5, math.MaxInt) selects the last five positions.
+func Range(start, stop int) Selector {
+	return rangeSelector{start: start, stop: stop}
+}
+
+func (r rangeSelector) positions(a axis) ([]int, error) {
+	if a.byLabel {
+		return nil, errors.New("Range selects by position; use LabelRange with Loc")
+	}
+	clamp := func(i int) int {
+		if i < 0 {
+			i += a.length
+		}
+		return max(0, min(i, a.length))
+	}
+	start, stop := clamp(r.start), clamp(r.stop)
+	out := make([]int, 0, max(0, stop-start))
+	for i := start; i < stop; i++ {
+		out = append(out, i)
+	}
+	return out, nil
+}
+
+type positionsSelector []int
+
+// Positions returns a positional Selector for the given positions, in the given order.
+// Negative positions count from the end; positions outside the axis are an error.
+func Positions(positions ...int) Selector {
+	return positionsSelector(positions)
+}
+
+func (p positionsSelector) positions(a axis) ([]int, error) {
+	if a.byLabel {
+		return nil, errors.New("Positions selects by position; use Labels with Loc")
+	}
+	out := make([]int, len(p))
+	for i, pos := range p {
+		if pos < 0 {
+			pos += a.length
+		}
+		if pos < 0 || pos >= a.length {
+			return nil, fmt.Errorf("%s position %d out of range for length %d", a.name, p[i], a.length)
+		}
+		out[i] = pos
+	}
+	return out, nil
+}
+
+type labelsSelector []any
+
+// Labels returns a label Selector for the given labels, in the given order: index labels
+// for rows and names for columns. A label shared by several rows selects all of them.
+// A label that is not present is an error.
+func Labels(labels ...any) Selector {
+	return labelsSelector(labels)
+}
+
+func (l labelsSelector) positions(a axis) ([]int, error) {
+	if !a.byLabel {
+		return nil, errors.New("Labels selects by label; use Positions with Iloc")
+	}
+	var out []int
+	for _, label := range l {
+		found, err := a.find(label)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, found...)
+	}
+	return out, nil
+}
+
+type labelRangeSelector struct {
+	from, to any
+}
+
+// LabelRange returns a label Selector for every position from the first occurrence of
+// from to the last occurrence of to, both inclusive, as in pandas .loc slicing. A nil
+// bound extends the range to that end of the axis. A bound that is not present is an error.
+func LabelRange(from, to any) Selector {
+	return labelRangeSelector{from: from, to: to}
+}
+
+func (l labelRangeSelector) positions(a axis) ([]int, error) {
+	if !a.byLabel {
+		return nil, errors.New("LabelRange selects by label; use Range with Iloc")
+	}
+	start, stop := 0, a.length-1
+	if l.from != nil {
+		found, err := a.find(l.from)
+		if err != nil {
+			return nil, err
+		}
+		start = found[0]
+	}
+	if l.to != nil {
+		found, err := a.find(l.to)
+		if err != nil {
+			return nil, err
+		}
+		stop = found[len(found)-1]
+	}
+	out := make([]int, 0, max(0, stop-start+1))
+	for i := start; i <= stop; i++ {
+		out = append(out, i)
+	}
+	return out, nil
+}
+
+// positions selects the positions where the mask is true.
+func (m Mask) positions(a axis) ([]int, error) {
+	if len(m) != a.length {
+		return nil, fmt.Errorf("mask length %d does not match %s count %d", len(m), a.name, a.length)
+	}
+	out := make([]int, 0, m.Count())
+	for i, keep := range m {
+		if keep {
+			out = append(out, i)
+		}
+	}
+	return out, nil
+}
+
+// Iloc returns a new DataFrame holding the rows and columns picked by position.
+//
+// Parameters:
+//   - rows: a Range, Positions, Mask or All (or nil) over the rows
+//   - cols: a Range, Positions, Mask or All (or nil) over the columns
+//
+// Returns:
+//   - A new DataFrame holding copies of the selected values; modifying it never affects df.
+//     Its index holds the labels of the selected rows.
+//   - An error if the DataFrame is nil or a selector is invalid or out of range.
+//
+// Example:
+//
+//	// First ten rows of the second and third columns
+//	top, err := df.Iloc(Range(0, 10), Positions(1, 2))
+//	// Last row, every column
+//	last, err := df.Iloc(Positions(-1), nil)
+func (df *DataFrame) Iloc(rows, cols Selector) (*DataFrame, error) {
+	return df.locate(rows, cols, false)
+}
+
+// Loc returns a new DataFrame holding the rows and columns picked by label: index labels
+// for rows and names for columns.
+//
+// Parameters:
+//   - rows: Labels, LabelRange, Mask or All (or nil) over the rows
+//   - cols: Labels, LabelRange, Mask or All (or nil) over the columns
+//
+// Returns:
+//   - A new DataFrame holding copies of the selected values; modifying it never affects df.
+//     Its index holds the labels of the selected rows.
+//   - An error if the DataFrame is nil, a selector is positional or a label is not present.
+//
+// Example:
+//
+//	byName, _ := df.SetIndex("name")
+//	// Rows labelled Alice and Bob, columns age to city
+//	subset, err := byName.Loc(Labels("Alice", "Bob"), LabelRange("age", "city"))
+func (df *DataFrame) Loc(rows, cols Selector) (*DataFrame, error) {
+	return df.locate(rows, cols, true)
+}
+
+// locate resolves the row and column selectors of Iloc (byLabel false) or Loc (byLabel
+// true) and gathers the selected values.
+func (df *DataFrame) locate(rows, cols Selector, byLabel bool) (*DataFrame, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+	if rows == nil {
+		rows = All()
+	}
+	if cols == nil {
+		cols = All()
+	}
+
+	df.Lock()
+	defer df.Unlock()
+
+	index := df.RowIndex()
+	rowPositions, err := rows.positions(axis{name: "row", length: index.Len(), byLabel: byLabel, lookup: index.Positions})
+	if err != nil {
+		return nil, err
+	}
+	colPositions, err := cols.positions(axis{name: "column", length: len(df.Columns), byLabel: byLabel, lookup: df.columnLookup})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &DataFrame{
+		Columns: make([]string, len(colPositions)),
+		Data:    make([]Series, len(colPositions)),
+		Index:   index.Take(rowPositions),
+	}
+	for i, pos := range colPositions {
+		out.Columns[i] = df.Columns[pos]
+		out.Data[i] = df.Data[pos].Take(rowPositions)
+	}
+	return out, nil
+}
+
+// columnLookup returns the positions of the columns named label.
+func (df *DataFrame) columnLookup(label any) []int {
+	name, ok := label.(string)
+	if !ok {
+		return nil
+	}
+	var out []int
+	for i, col := range df.Columns {
+		if col == name {
+			out = append(out, i)
+		}
+	}
+	return out
+}
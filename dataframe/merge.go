@@ -3,6 +3,11 @@ package dataframe
 import (
 	"errors"
 	"fmt"
+	"math"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
 )
 
 // MergeHow represents the type of merge operation
@@ -13,10 +18,127 @@ const (
 	RightMerge MergeHow = "right"
 	InnerMerge MergeHow = "inner"
 	FullMerge  MergeHow = "full"
+	// SemiMerge keeps the left rows that have a match on the right, once each, with the
+	// left columns only.
+	SemiMerge MergeHow = "semi"
+	// AntiMerge keeps the left rows that have no match on the right, with the left
+	// columns only.
+	AntiMerge MergeHow = "anti"
+	// CrossMerge pairs every left row with every right row. It takes no keys.
+	CrossMerge MergeHow = "cross"
 )
 
+// MergeIndicatorColumn is the name of the column added by MergeOptions.Indicator.
+const MergeIndicatorColumn = "_merge"
+
+// CollisionPolicy selects how a merge handles non-key columns whose name appears in both
+// DataFrames.
+type CollisionPolicy string
+
+const (
+	// CollisionsSuffix renames colliding columns with MergeOptions.Suffixes.
+	CollisionsSuffix CollisionPolicy = "suffix"
+	// CollisionsError fails the merge, naming the colliding columns.
+	CollisionsError CollisionPolicy = "error"
+)
+
+// MergeValidate names the relationship a merge expects between the keys of the two
+// DataFrames. The merge fails if the keys do not satisfy it.
+type MergeValidate string
+
+const (
+	// ValidateOneToOne requires keys to be unique in both DataFrames.
+	ValidateOneToOne MergeValidate = "one_to_one"
+	// ValidateOneToMany requires keys to be unique in the left DataFrame.
+	ValidateOneToMany MergeValidate = "one_to_many"
+	// ValidateManyToOne requires keys to be unique in the right DataFrame.
+	ValidateManyToOne MergeValidate = "many_to_one"
+	// ValidateManyToMany places no requirement on the keys.
+	ValidateManyToMany MergeValidate = "many_to_many"
+)
+
+// MergeEngine selects the algorithm a merge uses to pair up the rows of two DataFrames.
+type MergeEngine string
+
+const (
+	// AutoEngine uses ParallelHashEngine when the two DataFrames together hold enough rows
+	// for the parallel join to pay off and more than one CPU is available, and HashEngine
+	// otherwise.
+	AutoEngine MergeEngine = ""
+	// HashEngine builds one hash table over the keys of one side and probes it with the
+	// other, on a single goroutine.
+	HashEngine MergeEngine = "hash"
+	// ParallelHashEngine radix-partitions both sides by the hash of their keys and joins
+	// the partitions concurrently.
+	ParallelHashEngine MergeEngine = "parallel_hash"
+	// SortMergeEngine walks both sides in key order without hashing. Both DataFrames must
+	// already be sorted ascending by their merge keys, with null keys first; the merge
+	// fails otherwise. AutoEngine never picks it.
+	SortMergeEngine MergeEngine = "sort_merge"
+)
+
+// DefaultMergeSuffixes are the suffixes used when MergeOptions.Suffixes is not set.
+var DefaultMergeSuffixes = [2]string{"_x", "_y"}
+
+// MergeOptions configures DataFrame.MergeWith.
+//
+// The merge keys are given either by On, naming key columns present in both DataFrames,
+// or separately for each side by LeftOn / LeftIndex and RightOn / RightIndex. Several key
+// columns form a composite key: two rows match when every key column matches.
+//
+// Key values are compared by value rather than by Go type: integers of any width match
+// each other and integral floats (1 matches 1.0), []byte matches string and times match
+// the same instant in any location.
+type MergeOptions struct {
+	// How is the type of merge. The zero value performs an InnerMerge.
+	How MergeHow
+
+	// On names the key columns shared by both DataFrames. The result holds each of them
+	// once, filled from the right DataFrame for rows that only exist there.
+	On []string
+
+	// LeftOn and RightOn name the key columns of each DataFrame when they differ. They
+	// must have the same length and are paired in order. Both sets of columns are kept in
+	// the result, except that a pair with the same name is kept once, as with On.
+	LeftOn  []string
+	RightOn []string
+
+	// LeftIndex and RightIndex use the index labels of the left or right DataFrame as its
+	// single key instead of columns. When both are set the result is indexed by the joined
+	// labels; otherwise it gets a default RangeIndex.
+	LeftIndex  bool
+	RightIndex bool
+
+	// Suffixes are appended to the names of columns, other than shared keys, that appear
+	// in both DataFrames: Suffixes[0] to the left column and Suffixes[1] to the right one.
+	// Defaults to DefaultMergeSuffixes ("_x", "_y").
+	Suffixes [2]string
+	// OnCollision selects how such columns are handled (defaults to CollisionsSuffix).
+	OnCollision CollisionPolicy
+
+	// Validate checks that the keys are unique on the side or sides it names before
+	// merging. The zero value checks nothing.
+	Validate MergeValidate
+
+	// MatchNulls makes rows with null keys match each other. By default a null key, or a
+	// composite key with a null part, never matches anything, as in SQL. NaN floats count
+	// as null.
+	MatchNulls bool
+
+	// Indicator appends a MergeIndicatorColumn ("_merge") string column telling where each
+	// row comes from: "left_only", "right_only" or "both".
+	Indicator bool
+
+	// Engine selects the join algorithm. The zero value, AutoEngine, picks one from the
+	// size of the DataFrames. Every engine returns the same rows in the same order.
+	Engine MergeEngine
+}
+
 // Merge combines two DataFrames based on a specified column and merge type.
 //
+// Merge is a shorthand for MergeWith with a single shared key column; use MergeWith for
+// composite keys, differently named keys or index keys.
+//
 // Parameters:
 //
 // other: The DataFrame to merge with the current DataFrame.
@@ -27,7 +149,10 @@ const (
 //   - LeftMerge: Keep all rows from the left DataFrame and match rows from the right DataFrame.
 //   - RightMerge: Keep all rows from the right DataFrame and match rows from the left DataFrame.
 //   - InnerMerge: Keep only rows that have matching values in both DataFrames.
-//   - FullMerge: Keep all rows from both DataFrames, filling in missing values with nil.
+//   - FullMerge: Keep all rows from both DataFrames, filling in missing values with nulls.
+//   - SemiMerge: Keep the left rows that have a match, with the left columns only.
+//   - AntiMerge: Keep the left rows that have no match, with the left columns only.
+//   - CrossMerge: Pair every left row with every right row; on is ignored.
 //
 // Returns:
 //   - A new DataFrame containing the merged data.
@@ -38,19 +163,17 @@ const (
 //	// Create two sample DataFrames
 //	df1 := &DataFrame{
 //		Columns: []string{"ID", "Name"},
-//		Data: [][]any{
-//			{1, "Alice"},
-//			{2, "Bob"},
-//			{3, "Charlie"},
+//		Data: []Series{
+//			NewIntCol([]int64{1, 2, 3}),
+//			NewStringCol([]string{"Alice", "Bob", "Charlie"}),
 //		},
 //	}
 //
 //	df2 := &DataFrame{
 //		Columns: []string{"ID", "Age"},
-//		Data: [][]any{
-//			{1, 25},
-//			{2, 30},
-//			{4, 35},
+//		Data: []Series{
+//			NewIntCol([]int64{1, 2, 4}),
+//			NewIntCol([]int64{25, 30, 35}),
 //		},
 //	}
 //
@@ -67,7 +190,7 @@ const (
 //	// ID | Name    | Age
 //	// 1  | Alice   | 25
 //	// 2  | Bob     | 30
-//	// 3  | Charlie | nil
+//	// 3  | Charlie | NaN
 //
 //	// Full merge example (all rows from both)
 //	result, err := df1.Merge(df2, "ID", FullMerge)
@@ -75,279 +198,722 @@ const (
 //	// ID | Name    | Age
 //	// 1  | Alice   | 25
 //	// 2  | Bob     | 30
-//	// 3  | Charlie | nil
-//	// 4  | nil     | 35
+//	// 3  | Charlie | NaN
+//	// 4  | <null>  | 35
 func (df *DataFrame) Merge(other *DataFrame, on string, how MergeHow) (*DataFrame, error) {
+	opts := MergeOptions{How: how}
+	if how != CrossMerge {
+		opts.On = []string{on}
+	}
+	return df.MergeWith(other, opts)
+}
+
+// MergeWith combines two DataFrames on the keys described by opts.
+//
+// One side is hashed on its key values and the other probes the hash table. The order of
+// the output rows depends on opts.How:
+//   - InnerMerge, LeftMerge: left rows in order, each followed by its matching right rows
+//     in their original order
+//   - RightMerge: right rows in order, each preceded by its matching left rows in their
+//     original order
+//   - FullMerge: as LeftMerge, followed by the right rows that matched no left row, in
+//     their original order
+//   - SemiMerge, AntiMerge: the kept left rows in order
+//   - CrossMerge: left rows in order, each paired with every right row in order
+//
+// Parameters:
+//   - other: the right DataFrame of the merge
+//   - opts: the merge type and keys; see MergeOptions
+//
+// Returns:
+//   - A new DataFrame holding every left column followed by the right columns that are
+//     not shared keys. Columns present on both sides are suffixed, "_x" and "_y" by default.
+//   - An error if the keys are missing, inconsistent or not present, the merge type is
+//     invalid, column names collide under CollisionsError, the keys fail Validate or,
+//     with SortMergeEngine, either DataFrame is not sorted by its keys.
+//
+// Example:
+//
+//	// orders has columns customer, year, total; targets has columns client, year, goal
+//	result, err := orders.MergeWith(targets, MergeOptions{
+//	    How:     LeftMerge,
+//	    LeftOn:  []string{"customer", "year"},
+//	    RightOn: []string{"client", "year"},
+//	})
+//	// customer | year | total | client | goal
+//
+//	// customers without any order, with the side each row came from
+//	idle, err := customers.MergeWith(orders, MergeOptions{How: AntiMerge, On: []string{"customer"}, Indicator: true})
+//	// customer | region | _merge
+//	// umbrella | east   | left_only
+func (df *DataFrame) MergeWith(other *DataFrame, opts MergeOptions) (*DataFrame, error) {
 	if df == nil || other == nil {
 		return nil, errors.New("both DataFrames must be non-nil")
 	}
 
-	// Validate 'on' column exists in both DataFrames
-	df1ColIdx := -1
-	df2ColIdx := -1
-	for i, col := range df.Columns {
-		if col == on {
-			df1ColIdx = i
-			break
-		}
-	}
-	for i, col := range other.Columns {
-		if col == on {
-			df2ColIdx = i
-			break
-		}
+	plan, err := planMerge(df, other, opts)
+	if err != nil {
+		return nil, err
 	}
-	if df1ColIdx == -1 || df2ColIdx == -1 {
-		return nil, fmt.Errorf("column '%s' not found in both DataFrames", on)
+	if plan.how == CrossMerge {
+		return plan.assemble(performCrossMerge(df.rowCount(), other.rowCount()))
 	}
 
-	// Create maps for faster lookups
-	df2Map := make(map[any][]int)
-	for i, row := range other.Data {
-		key := row[df2ColIdx]
-		df2Map[key] = append(df2Map[key], i)
+	leftKeys := rowKeys(plan.leftKeys, plan.matchNulls)
+	rightKeys := rowKeys(plan.rightKeys, plan.matchNulls)
+	if err := plan.validate(leftKeys, rightKeys); err != nil {
+		return nil, err
 	}
 
-	// Prepare result columns
-	resultColumns := make([]string, 0)
-	resultColumns = append(resultColumns, df.Columns...)
-	for _, col := range other.Columns {
-		if col != on {
-			resultColumns = append(resultColumns, col)
+	// Pair up left and right rows based on merge type
+	switch plan.engine {
+	case SortMergeEngine:
+		pairs, err := sortMerge(plan.how, plan.leftKeys, plan.rightKeys, plan.matchNulls)
+		if err != nil {
+			return nil, err
 		}
+		return plan.assemble(pairs)
+	case ParallelHashEngine:
+		return plan.assemble(parallelHashMerge(plan.how, leftKeys, rightKeys))
 	}
-
-	// Prepare result data based on merge type
-	var resultData [][]any
-	switch how {
+	rightMap := buildKeyMap(rightKeys)
+	var pairs mergePairs
+	switch plan.how {
 	case InnerMerge:
-		resultData = performInnerMerge(df, other, df1ColIdx, df2ColIdx, df2Map)
+		pairs = performInnerMerge(leftKeys, rightKeys, rightMap)
 	case LeftMerge:
-		resultData = performLeftMerge(df, other, df1ColIdx, df2ColIdx, df2Map)
+		pairs = performLeftMerge(leftKeys, rightKeys, rightMap)
 	case RightMerge:
-		resultData = performRightMerge(df, other, df1ColIdx, df2ColIdx, df2Map)
+		pairs = performRightMerge(leftKeys, rightKeys, rightMap)
 	case FullMerge:
-		resultData = performFullMerge(df, other, df1ColIdx, df2ColIdx, df2Map)
+		pairs = performFullMerge(leftKeys, rightKeys, rightMap)
+	case SemiMerge:
+		pairs = performSemiMerge(leftKeys, rightMap)
+	case AntiMerge:
+		pairs = performAntiMerge(leftKeys, rightMap)
+	}
+
+	return plan.assemble(pairs)
+}
+
+// mergePlan is a validated merge: the two DataFrames, their key values and how the key
+// columns appear in the result.
+type mergePlan struct {
+	left, right *DataFrame
+	how         MergeHow
+
+	// leftKeys and rightKeys hold the key values of each side, one Series per key.
+	leftKeys, rightKeys []Series
+	// shared maps the position of a left key column to the position of the right key
+	// column with the same name; the pair is coalesced into one result column.
+	shared map[int]int
+	// indexed is true when both sides are keyed by their index.
+	indexed bool
+
+	suffixes    [2]string
+	onCollision CollisionPolicy
+	validation  MergeValidate
+	indicator   bool
+	matchNulls  bool
+	engine      MergeEngine
+}
+
+// planMerge resolves the keys of opts against both DataFrames.
+func planMerge(left, right *DataFrame, opts MergeOptions) (*mergePlan, error) {
+	plan := &mergePlan{
+		left:        left,
+		right:       right,
+		how:         opts.How,
+		shared:      make(map[int]int),
+		suffixes:    opts.Suffixes,
+		onCollision: opts.OnCollision,
+		validation:  opts.Validate,
+		indicator:   opts.Indicator,
+		matchNulls:  opts.MatchNulls,
+		engine:      opts.Engine,
+	}
+	if plan.how == "" {
+		plan.how = InnerMerge
+	}
+	if plan.suffixes == [2]string{} {
+		plan.suffixes = DefaultMergeSuffixes
+	}
+	if plan.onCollision == "" {
+		plan.onCollision = CollisionsSuffix
+	}
+	switch plan.onCollision {
+	case CollisionsSuffix, CollisionsError:
+	default:
+		return nil, fmt.Errorf("invalid collision policy: %s", plan.onCollision)
+	}
+	switch plan.validation {
+	case "", ValidateOneToOne, ValidateOneToMany, ValidateManyToOne, ValidateManyToMany:
 	default:
-		return nil, fmt.Errorf("invalid merge type: %s", how)
+		return nil, fmt.Errorf("invalid merge validation: %s", plan.validation)
+	}
+	switch plan.how {
+	case InnerMerge, LeftMerge, RightMerge, FullMerge, SemiMerge, AntiMerge, CrossMerge:
+	default:
+		return nil, fmt.Errorf("invalid merge type: %s", plan.how)
+	}
+	switch plan.engine {
+	case AutoEngine:
+		plan.engine = HashEngine
+		if left.rowCount()+right.rowCount() >= parallelMergeThreshold && runtime.GOMAXPROCS(0) > 1 {
+			plan.engine = ParallelHashEngine
+		}
+	case HashEngine, ParallelHashEngine, SortMergeEngine:
+	default:
+		return nil, fmt.Errorf("invalid merge engine: %s", plan.engine)
+	}
+
+	if plan.how == CrossMerge {
+		if len(opts.On) > 0 || len(opts.LeftOn) > 0 || len(opts.RightOn) > 0 || opts.LeftIndex || opts.RightIndex {
+			return nil, errors.New("CrossMerge takes no merge keys")
+		}
+		return plan, nil
+	}
+
+	leftOn, rightOn := opts.LeftOn, opts.RightOn
+	if len(opts.On) > 0 {
+		if len(leftOn) > 0 || len(rightOn) > 0 || opts.LeftIndex || opts.RightIndex {
+			return nil, errors.New("On cannot be combined with LeftOn, RightOn, LeftIndex or RightIndex")
+		}
+		for _, col := range opts.On {
+			if !slices.Contains(left.Columns, col) || !slices.Contains(right.Columns, col) {
+				return nil, fmt.Errorf("column '%s' not found in both DataFrames", col)
+			}
+		}
+		leftOn, rightOn = opts.On, opts.On
+	}
+
+	leftPos, err := resolveMergeKeys(left, "Left", leftOn, opts.LeftIndex, &plan.leftKeys)
+	if err != nil {
+		return nil, err
+	}
+	rightPos, err := resolveMergeKeys(right, "Right", rightOn, opts.RightIndex, &plan.rightKeys)
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.leftKeys) != len(plan.rightKeys) {
+		return nil, fmt.Errorf("left and right merge keys differ in number: %d and %d", len(plan.leftKeys), len(plan.rightKeys))
+	}
+
+	for k := range leftPos {
+		l, r := leftPos[k], rightPos[k]
+		if l >= 0 && r >= 0 && left.Columns[l] == right.Columns[r] {
+			plan.shared[l] = r
+		}
 	}
+	plan.indexed = opts.LeftIndex && opts.RightIndex
+	return plan, nil
+}
 
-	return &DataFrame{
-		Columns: resultColumns,
-		Data:    resultData,
-	}, nil
+// resolveMergeKeys appends the key values of one side of a merge to keys and returns the
+// column position of each key, or -1 for the index. side is "Left" or "Right".
+func resolveMergeKeys(df *DataFrame, side string, on []string, useIndex bool, keys *[]Series) ([]int, error) {
+	switch {
+	case useIndex && len(on) > 0:
+		return nil, fmt.Errorf("%sOn cannot be combined with %sIndex", side, side)
+	case useIndex:
+		labels := df.RowIndex().Labels()
+		if err := checkHashableKeys(labels); err != nil {
+			return nil, fmt.Errorf("%s index: %w", strings.ToLower(side), err)
+		}
+		*keys = append(*keys, labels)
+		return []int{-1}, nil
+	case len(on) == 0:
+		return nil, fmt.Errorf("no %s merge keys: set On, %sOn or %sIndex", strings.ToLower(side), side, side)
+	}
+
+	positions, err := df.columnPositions(on)
+	if err != nil {
+		return nil, fmt.Errorf("%s DataFrame: %w", strings.ToLower(side), err)
+	}
+	for _, pos := range positions {
+		if err := checkHashableKeys(df.Data[pos]); err != nil {
+			return nil, fmt.Errorf("%s key '%s': %w", strings.ToLower(side), df.Columns[pos], err)
+		}
+		*keys = append(*keys, df.Data[pos])
+	}
+	return positions, nil
 }
 
-// performInnerMerge combines two DataFrames based on a specified column index,
-// returning only the rows that have matching values in both DataFrames.
+// validate checks the key uniqueness required by the Validate option.
+func (p *mergePlan) validate(leftKeys, rightKeys []any) error {
+	checkLeft := p.validation == ValidateOneToOne || p.validation == ValidateOneToMany
+	checkRight := p.validation == ValidateOneToOne || p.validation == ValidateManyToOne
+	if checkLeft {
+		if err := checkUniqueKeys(leftKeys, buildKeyMap(leftKeys), p.leftKeys, "left", p.validation); err != nil {
+			return err
+		}
+	}
+	if checkRight {
+		if err := checkUniqueKeys(rightKeys, buildKeyMap(rightKeys), p.rightKeys, "right", p.validation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkUniqueKeys returns an error describing the first key, in row order, that appears
+// on more than one row of one side of a merge.
+func checkUniqueKeys(keys []any, keyMap map[any][]int, cols []Series, side string, validation MergeValidate) error {
+	for _, key := range keys {
+		if rows := keyMap[key]; len(rows) > 1 {
+			values := make([]string, len(cols))
+			for i, col := range cols {
+				values[i] = displayValue(col, rows[0])
+			}
+			return fmt.Errorf("merge keys are not unique in the %s DataFrame: key (%s) appears in %d rows, starting at rows %d and %d; not a %s merge",
+				side, strings.Join(values, ", "), len(rows), rows[0], rows[1], validation)
+		}
+	}
+	return nil
+}
+
+// assemble materializes the paired rows into the merged DataFrame.
 //
-// Parameters:
-//   - df1: The first DataFrame to merge.
-//   - df2: The second DataFrame to merge.
-//   - df1ColIdx: The index of the column in the first DataFrame to merge on.
-//   - df2ColIdx: The index of the column in the second DataFrame to merge on.
-//   - df2Map: A map created from the second DataFrame for faster lookups, where the key is the value
-//     in the merge column and the value is a slice of indices of rows in the second DataFrame that
-//     have that key.
+// The result contains every column of the left DataFrame followed by every column of the
+// right DataFrame except the shared key columns. A shared key column is filled with the
+// right key for rows that only exist on the right side, so the key is never lost. Other
+// columns named alike on both sides are suffixed, or rejected under CollisionsError.
+func (p *mergePlan) assemble(pairs mergePairs) (*DataFrame, error) {
+	out := &DataFrame{
+		Columns: make([]string, 0, len(p.left.Columns)+len(p.right.Columns)),
+		Data:    make([]Series, 0, len(p.left.Columns)+len(p.right.Columns)),
+	}
+	dropped := make(map[int]bool, len(p.right.Columns))
+	for _, r := range p.shared {
+		dropped[r] = true
+	}
+	if p.how == SemiMerge || p.how == AntiMerge {
+		for c := range p.right.Columns {
+			dropped[c] = true
+		}
+	}
+
+	// Columns kept from both sides under the same name collide; shared keys are kept once
+	// and so never do
+	leftNames := make(map[string]bool, len(p.left.Columns))
+	for _, name := range p.left.Columns {
+		leftNames[name] = true
+	}
+	collisions := make(map[string]bool)
+	for c, name := range p.right.Columns {
+		if !dropped[c] && leftNames[name] {
+			collisions[name] = true
+		}
+	}
+	if len(collisions) > 0 && p.onCollision == CollisionsError {
+		names := make([]string, 0, len(collisions))
+		for name := range collisions {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		return nil, fmt.Errorf("columns present in both DataFrames: %s", strings.Join(names, ", "))
+	}
+
+	for c, col := range p.left.Data {
+		name := p.left.Columns[c]
+		if r, ok := p.shared[c]; ok {
+			out.Columns = append(out.Columns, name)
+			out.Data = append(out.Data, pairs.gatherKey(col, p.right.Data[r]))
+			continue
+		}
+		if collisions[name] {
+			name += p.suffixes[0]
+		}
+		out.Columns = append(out.Columns, name)
+		out.Data = append(out.Data, col.Take(pairs.left))
+	}
+	for c, col := range p.right.Data {
+		if dropped[c] {
+			continue
+		}
+		name := p.right.Columns[c]
+		if collisions[name] {
+			name += p.suffixes[1]
+		}
+		out.Columns = append(out.Columns, name)
+		out.Data = append(out.Data, col.Take(pairs.right))
+	}
+	if err := checkUniqueColumns(out.Columns); err != nil {
+		return nil, fmt.Errorf("merged column names collide after adding suffixes: %w", err)
+	}
+	if p.indicator {
+		if slices.Contains(out.Columns, MergeIndicatorColumn) {
+			return nil, fmt.Errorf("cannot add indicator: the column '%s' already exists", MergeIndicatorColumn)
+		}
+		out.Columns = append(out.Columns, MergeIndicatorColumn)
+		out.Data = append(out.Data, pairs.indicator())
+	}
+
+	if p.indexed {
+		labels := pairs.gatherKey(p.left.RowIndex().Labels(), p.right.RowIndex().Labels())
+		out.Index = NewLabelIndex(p.left.RowIndex().Name(), labels)
+	}
+	return out, nil
+}
+
+// mergePairs holds the row pairings produced by a merge strategy.
+//
+// left[i] and right[i] are the row positions in the left and right DataFrames that
+// make up output row i. A position of -1 means the output row has no counterpart on
+// that side and its columns are filled with nulls.
+type mergePairs struct {
+	left  []int
+	right []int
+}
+
+// add records a single output row made of left row l and right row r.
+func (p *mergePairs) add(l, r int) {
+	p.left = append(p.left, l)
+	p.right = append(p.right, r)
+}
+
+// indicator builds the MergeIndicatorColumn of the result, naming the side or sides each
+// output row comes from.
+func (p mergePairs) indicator() Series {
+	sides := make([]string, len(p.left))
+	for i := range sides {
+		switch {
+		case p.left[i] < 0:
+			sides[i] = "right_only"
+		case p.right[i] < 0:
+			sides[i] = "left_only"
+		default:
+			sides[i] = "both"
+		}
+	}
+	return NewStringCol(sides)
+}
+
+// gatherKey builds a shared key column of the result, taking the key from the left
+// column and falling back to the right column for rows that only exist on the right.
 //
-// Returns: A slice of slices containing the merged data, where each inner slice represents a row.
-// The resulting rows will include all columns from the first DataFrame and the columns from the second DataFrame, excluding the merge column from the second DataFrame.
+// The result keeps the dtype of the left column whenever the right-only keys fit in it,
+// so that the join type does not change the dtype of the key: int keys stay ints when the
+// right keys are integral floats. Otherwise the two columns combine as in concatSeries.
+func (p mergePairs) gatherKey(leftKey, rightKey Series) Series {
+	var rightRows []int
+	rows := make([]int, len(p.left))
+	for i, l := range p.left {
+		rows[i] = l
+		if l < 0 {
+			rows[i] = leftKey.Len() + len(rightRows)
+			rightRows = append(rightRows, p.right[i])
+		}
+	}
+	if len(rightRows) == 0 {
+		return leftKey.Take(p.left)
+	}
+	rightOnly := castIntegral(rightKey.Take(rightRows), leftKey.DType())
+	return concatSeries([]Series{leftKey, rightOnly}).Take(rows)
+}
+
+// castIntegral returns col as an IntCol when it is a FloatCol, dtype is IntType and every
+// value is a whole number within the int64 range. Otherwise it returns col unchanged.
+func castIntegral(col Series, dtype DType) Series {
+	floats, ok := col.(*FloatCol)
+	if !ok || dtype != IntType {
+		return col
+	}
+	ints := NewIntCol(make([]int64, floats.Len()))
+	for i, v := range floats.data {
+		if floats.IsNull(i) {
+			ints.SetNull(i)
+			continue
+		}
+		if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+			return col
+		}
+		ints.data[i] = int64(v)
+	}
+	return ints
+}
+
+// nullKey is the key of a row with a null key value when MergeOptions.MatchNulls is set,
+// so that such rows match each other.
+type nullKey struct{}
+
+// rowKeys returns the hashable key of every row given the key values of one side of a
+// merge. A single key column gives its normalized values; several key columns give a
+// compositeKey of normalized values per row.
 //
-// Example:
+// A row with a null in any key column gets a nil key, which buildKeyMap leaves out, so it
+// never matches; with matchNulls it gets a nullKey (or a nullKey part in a composite key)
+// instead and matches the other null rows.
 //
-//	result := performInnerMerge(df1, df2, 0, 0, df2Map)
-//	// This will merge df1 and df2 on the first column of each DataFrame,
-//	// returning only the rows with matching values in that column.
-func performInnerMerge(df1, df2 *DataFrame, df1ColIdx, df2ColIdx int, df2Map map[any][]int) [][]any {
-	if df1 == nil || df2 == nil {
-		return nil
-	}
-	var result [][]any
-	for _, row1 := range df1.Data {
-		key := row1[df1ColIdx]
-		if matches, ok := df2Map[key]; ok {
-			for _, matchIdx := range matches {
-				row2 := df2.Data[matchIdx]
-				newRow := make([]any, 0)
-				newRow = append(newRow, row1...)
-				for j, val := range row2 {
-					if j != df2ColIdx {
-						newRow = append(newRow, val)
-					}
+// Keys of large DataFrames are built concurrently in contiguous chunks of rows.
+func rowKeys(cols []Series, matchNulls bool) []any {
+	keys := make([]any, cols[0].Len())
+	chunks := [][2]int{{0, len(keys)}}
+	if len(keys) >= parallelMergeThreshold/2 {
+		chunks = splitRows(len(keys))
+	}
+	parallelChunks(chunks, func(_, start, end int) {
+		rowKeysRange(keys, cols, matchNulls, start, end)
+	})
+	return keys
+}
+
+// rowKeysRange fills keys[start:end] for rowKeys.
+func rowKeysRange(keys []any, cols []Series, matchNulls bool, start, end int) {
+	if len(cols) == 1 {
+		for i := start; i < end; i++ {
+			keys[i] = normalizeKey(cols[0].At(i))
+			if keys[i] == nil && matchNulls {
+				keys[i] = nullKey{}
+			}
+		}
+		return
+	}
+
+	parts := make([]any, len(cols))
+rows:
+	for i := start; i < end; i++ {
+		for c, col := range cols {
+			part := normalizeKey(col.At(i))
+			if part == nil {
+				if !matchNulls {
+					continue rows
 				}
-				result = append(result, newRow)
+				part = nullKey{}
 			}
+			parts[c] = part
 		}
+		keys[i] = newCompositeKey(parts)
 	}
-	return result
 }
 
-// performLeftMerge combines two DataFrames based on a specified column index,
-// keeping all rows from the first DataFrame and matching rows from the second DataFrame.
+// normalizeKey converts a key value so that equal values hash alike across column types:
+// integers of every width become int64, floats with an integral value become int64 as
+// well, times are moved to UTC and []byte becomes string. NaN is treated as null and
+// returned as nil.
+func normalizeKey(v any) any {
+	v = normalizeValue(v)
+	switch x := v.(type) {
+	case float64:
+		if math.IsNaN(x) {
+			return nil
+		}
+		if x == math.Trunc(x) && x >= math.MinInt64 && x < math.MaxInt64 {
+			return int64(x)
+		}
+	case time.Time:
+		return x.UTC()
+	}
+	return v
+}
+
+// checkHashableKeys is checkHashable for merge keys, which are hashed after normalizeKey:
+// a []byte value is hashed as a string.
+func checkHashableKeys(col Series) error {
+	return checkHashableAs(col, normalizeKey)
+}
+
+// compositeKeyWidth is the number of key parts held directly by a compositeKey.
+const compositeKeyWidth = 4
+
+// compositeKey is the key of a row merged on several columns. It holds the normalized key
+// values themselves rather than a rendering of them, so two keys are equal under == exactly
+// when every pair of parts is. Parts beyond the first compositeKeyWidth are chained
+// through more, which holds another compositeKey, or nil.
+type compositeKey struct {
+	parts [compositeKeyWidth]any
+	more  any
+}
+
+// newCompositeKey returns the compositeKey holding parts. Every key of a merge side has
+// the same number of parts.
+func newCompositeKey(parts []any) compositeKey {
+	var key compositeKey
+	copy(key.parts[:], parts)
+	if len(parts) > compositeKeyWidth {
+		key.more = newCompositeKey(parts[compositeKeyWidth:])
+	}
+	return key
+}
+
+// buildKeyMap maps every row key to the row positions holding it. Rows with a nil (null)
+// key are left out.
+func buildKeyMap(keys []any) map[any][]int {
+	keyMap := make(map[any][]int)
+	for i, key := range keys {
+		if key == nil {
+			continue
+		}
+		keyMap[key] = append(keyMap[key], i)
+	}
+	return keyMap
+}
+
+// performInnerMerge pairs the rows of two DataFrames that have matching keys, returning
+// only the rows that have a match in both DataFrames.
 //
 // Parameters:
-//   - df1: The first DataFrame to merge.
-//   - df2: The second DataFrame to merge.
-//   - df1ColIdx: The index of the column in the first DataFrame to merge on.
-//   - df2ColIdx: The index of the column in the second DataFrame to merge on.
-//   - df2Map: A map created from the second DataFrame for faster lookups, where the key is the value
-//     in the merge column and the value is a slice of indices of rows in the second DataFrame that
-//     have that key.
+//   - leftKeys: The key of every row of the left DataFrame, as built by rowKeys.
+//   - rightKeys: The key of every row of the right DataFrame.
+//   - rightMap: A map created from rightKeys for faster lookups, where the key is a row key
+//     and the value is a slice of indices of rows in the right DataFrame that have that key.
 //
-// Returns: A slice of slices containing the merged data, where each inner slice represents a row.
-// The resulting rows will include all rows from the first DataFrame, with matching data from the second DataFrame where available, and nil values where no match exists.
+// Returns: The row pairings of the merge. Every output row has a counterpart in both DataFrames.
 //
 // Example:
 //
-//	result := performLeftMerge(df1, df2, 0, 0, df2Map)
-//	// This will keep all rows from df1 and add matching columns from df2,
-//	// filling with nil values when there's no match in df2.
-func performLeftMerge(df1, df2 *DataFrame, df1ColIdx, df2ColIdx int, df2Map map[any][]int) [][]any {
-	if df1 == nil || df2 == nil {
-		return nil
+//	pairs := performInnerMerge(leftKeys, rightKeys, buildKeyMap(rightKeys))
+//	// This pairs every left row with each right row holding the same key,
+//	// leaving out the rows without a match.
+func performInnerMerge(leftKeys, rightKeys []any, rightMap map[any][]int) mergePairs {
+	var pairs mergePairs
+	for i, key := range leftKeys {
+		for _, matchIdx := range rightMap[key] {
+			pairs.add(i, matchIdx)
+		}
 	}
-	var result [][]any
-	nullRow := make([]any, len(df2.Columns)-1)
+	return pairs
+}
 
-	for _, row1 := range df1.Data {
-		key := row1[df1ColIdx]
-		if matches, ok := df2Map[key]; ok {
+// performLeftMerge pairs the rows of two DataFrames that have matching keys, keeping all
+// rows from the left DataFrame and matching rows from the right DataFrame.
+//
+// Parameters:
+//   - leftKeys: The key of every row of the left DataFrame, as built by rowKeys.
+//   - rightKeys: The key of every row of the right DataFrame.
+//   - rightMap: A map created from rightKeys for faster lookups, where the key is a row key
+//     and the value is a slice of indices of rows in the right DataFrame that have that key.
+//
+// Returns: The row pairings of the merge. Every row of the left DataFrame appears at least once,
+// paired with -1 when no match exists in the right DataFrame.
+//
+// Example:
+//
+//	pairs := performLeftMerge(leftKeys, rightKeys, buildKeyMap(rightKeys))
+//	// This keeps all left rows and pairs them with matching right rows,
+//	// filling with null values when there's no match on the right.
+func performLeftMerge(leftKeys, rightKeys []any, rightMap map[any][]int) mergePairs {
+	var pairs mergePairs
+	for i, key := range leftKeys {
+		if matches, ok := rightMap[key]; ok {
 			for _, matchIdx := range matches {
-				row2 := df2.Data[matchIdx]
-				newRow := make([]any, 0)
-				newRow = append(newRow, row1...)
-				for j, val := range row2 {
-					if j != df2ColIdx {
-						newRow = append(newRow, val)
-					}
-				}
-				result = append(result, newRow)
+				pairs.add(i, matchIdx)
 			}
 		} else {
-			newRow := make([]any, 0)
-			newRow = append(newRow, row1...)
-			newRow = append(newRow, nullRow...)
-			result = append(result, newRow)
+			pairs.add(i, -1)
 		}
 	}
-	return result
+	return pairs
 }
 
-// performRightMerge combines two DataFrames based on a specified column index,
-// keeping all rows from the second DataFrame and matching rows from the first DataFrame.
+// performRightMerge pairs the rows of two DataFrames that have matching keys, keeping all
+// rows from the right DataFrame and matching rows from the left DataFrame.
 //
 // Parameters:
-//   - df1: The first DataFrame to merge.
-//   - df2: The second DataFrame to merge.
-//   - df1ColIdx: The index of the column in the first DataFrame to merge on.
-//   - df2ColIdx: The index of the column in the second DataFrame to merge on.
-//   - df2Map: A map created from the second DataFrame for faster lookups (unused in right merge).
+//   - leftKeys: The key of every row of the left DataFrame, as built by rowKeys.
+//   - rightKeys: The key of every row of the right DataFrame.
+//   - rightMap: A map created from rightKeys for faster lookups (unused in right merge).
 //
-// Returns: A slice of slices containing the merged data, where each inner slice represents a row.
-// The resulting rows will include all rows from the second DataFrame, with matching data from the first DataFrame where available, and nil values where no match exists.
+// Returns: The row pairings of the merge. Every row of the right DataFrame appears at least once,
+// paired with -1 when no match exists in the left DataFrame.
 //
 // Example:
 //
-//	result := performRightMerge(df1, df2, 0, 0, df2Map)
-//	// This will keep all rows from df2 and add matching columns from df1,
-//	// filling with nil values when there's no match in df1.
-func performRightMerge(df1, df2 *DataFrame, df1ColIdx, df2ColIdx int, _ map[any][]int) [][]any {
-	if df1 == nil || df2 == nil {
-		return nil
-	}
-	// Create reverse mapping for df1
-	df1Map := make(map[any][]int)
-	for i, row := range df1.Data {
-		key := row[df1ColIdx]
-		df1Map[key] = append(df1Map[key], i)
-	}
+//	pairs := performRightMerge(leftKeys, rightKeys, nil)
+//	// This keeps all right rows and pairs them with matching left rows,
+//	// filling with null values when there's no match on the left.
+func performRightMerge(leftKeys, rightKeys []any, _ map[any][]int) mergePairs {
+	var pairs mergePairs
+	// Create reverse mapping for the left side
+	leftMap := buildKeyMap(leftKeys)
 
-	var result [][]any
-	nullRow := make([]any, len(df1.Columns))
-
-	for _, row2 := range df2.Data {
-		key := row2[df2ColIdx]
-		if matches, ok := df1Map[key]; ok {
+	for j, key := range rightKeys {
+		if matches, ok := leftMap[key]; ok {
 			for _, matchIdx := range matches {
-				row1 := df1.Data[matchIdx]
-				newRow := make([]any, 0)
-				newRow = append(newRow, row1...)
-				for j, val := range row2 {
-					if j != df2ColIdx {
-						newRow = append(newRow, val)
-					}
-				}
-				result = append(result, newRow)
+				pairs.add(matchIdx, j)
 			}
 		} else {
-			newRow := make([]any, 0)
-			// Set the key column value instead of using null
-			nullRow[df1ColIdx] = key
-			newRow = append(newRow, nullRow...)
-			for j, val := range row2 {
-				if j != df2ColIdx {
-					newRow = append(newRow, val)
-				}
-			}
-			result = append(result, newRow)
-			// Reset the key column back to nil for next iteration
-			nullRow[df1ColIdx] = nil
+			pairs.add(-1, j)
 		}
 	}
-	return result
+	return pairs
 }
 
-// performFullMerge combines two DataFrames based on a specified column index,
-// keeping all rows from both DataFrames and matching where possible.
+// performFullMerge pairs the rows of two DataFrames that have matching keys, keeping all
+// rows from both DataFrames and matching where possible.
 //
 // Parameters:
-//   - df1: The first DataFrame to merge.
-//   - df2: The second DataFrame to merge.
-//   - df1ColIdx: The index of the column in the first DataFrame to merge on.
-//   - df2ColIdx: The index of the column in the second DataFrame to merge on.
-//   - df2Map: A map created from the second DataFrame for faster lookups, where the key is the value
-//     in the merge column and the value is a slice of indices of rows in the second DataFrame that
-//     have that key.
+//   - leftKeys: The key of every row of the left DataFrame, as built by rowKeys.
+//   - rightKeys: The key of every row of the right DataFrame.
+//   - rightMap: A map created from rightKeys for faster lookups, where the key is a row key
+//     and the value is a slice of indices of rows in the right DataFrame that have that key.
 //
-// Returns: A slice of slices containing the merged data, where each inner slice represents a row.
-// The resulting rows will include all rows from both DataFrames, with matching data where available and nil values where no match exists.
+// Returns: The row pairings of the merge. Every row of both DataFrames appears at least once,
+// paired with -1 when no match exists on the other side.
 //
 // Example:
 //
-//	result := performFullMerge(df1, df2, 0, 0, df2Map)
-//	// This will keep all rows from both df1 and df2, matching where possible,
-//	// filling with nil values when there's no match in either DataFrame.
-func performFullMerge(df1, df2 *DataFrame, df1ColIdx, df2ColIdx int, df2Map map[any][]int) [][]any {
-	if df1 == nil || df2 == nil {
-		return nil
-	}
+//	pairs := performFullMerge(leftKeys, rightKeys, buildKeyMap(rightKeys))
+//	// This keeps all rows from both sides, matching where possible,
+//	// filling with null values when there's no match on either side.
+func performFullMerge(leftKeys, rightKeys []any, rightMap map[any][]int) mergePairs {
 	// Get all rows from left merge
-	result := performLeftMerge(df1, df2, df1ColIdx, df2ColIdx, df2Map)
+	pairs := performLeftMerge(leftKeys, rightKeys, rightMap)
 
-	// Create set of keys already processed
-	processedKeys := make(map[any]bool)
-	for _, row := range df1.Data {
-		processedKeys[row[df1ColIdx]] = true
+	// Mark the right rows the left merge paired up. Tracking rows rather than keys keeps
+	// right rows whose key also appears on the left without matching, such as null keys.
+	matched := make([]bool, len(rightKeys))
+	for _, r := range pairs.right {
+		if r >= 0 {
+			matched[r] = true
+		}
 	}
 
 	// Add remaining rows from right DataFrame
-	nullRow := make([]any, len(df1.Columns))
-	for _, row2 := range df2.Data {
-		key := row2[df2ColIdx]
-		if !processedKeys[key] {
-			newRow := make([]any, 0)
-			// Set the key column value instead of using null
-			nullRow[df1ColIdx] = key
-			newRow = append(newRow, nullRow...)
-			for j, val := range row2 {
-				if j != df2ColIdx {
-					newRow = append(newRow, val)
-				}
-			}
-			result = append(result, newRow)
-			// Reset the key column back to nil for next iteration
-			nullRow[df1ColIdx] = nil
+	for j := range rightKeys {
+		if !matched[j] {
+			pairs.add(-1, j)
+		}
+	}
+	return pairs
+}
+
+// performSemiMerge keeps every row of the left DataFrame whose key appears in rightMap,
+// once each and in order. Each output row is paired with its first match so that the
+// pairing reads as "both"; semi merges never gather right columns.
+func performSemiMerge(leftKeys []any, rightMap map[any][]int) mergePairs {
+	var pairs mergePairs
+	for i, key := range leftKeys {
+		if matches, ok := rightMap[key]; ok {
+			pairs.add(i, matches[0])
+		}
+	}
+	return pairs
+}
+
+// performAntiMerge keeps every row of the left DataFrame whose key does not appear in
+// rightMap, in order, paired with -1.
+func performAntiMerge(leftKeys []any, rightMap map[any][]int) mergePairs {
+	var pairs mergePairs
+	for i, key := range leftKeys {
+		if _, ok := rightMap[key]; !ok {
+			pairs.add(i, -1)
+		}
+	}
+	return pairs
+}
+
+// performCrossMerge pairs every one of leftRows rows with every one of rightRows rows,
+// left row by left row.
+func performCrossMerge(leftRows, rightRows int) mergePairs {
+	pairs := mergePairs{
+		left:  make([]int, 0, leftRows*rightRows),
+		right: make([]int, 0, leftRows*rightRows),
+	}
+	for i := 0; i < leftRows; i++ {
+		for j := 0; j < rightRows; j++ {
+			pairs.add(i, j)
 		}
 	}
-	return result
+	return pairs
 }
@@ -0,0 +1,242 @@
+package dataframe
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// AsofDirection selects which right row MergeAsof pairs with a left row.
+type AsofDirection string
+
+const (
+	// AsofBackward matches the last right row whose key is less than or equal to the
+	// left key.
+	AsofBackward AsofDirection = "backward"
+	// AsofForward matches the first right row whose key is greater than or equal to the
+	// left key.
+	AsofForward AsofDirection = "forward"
+	// AsofNearest matches the closer of the backward and forward matches, preferring the
+	// backward one on a tie.
+	AsofNearest AsofDirection = "nearest"
+)
+
+// MergeAsof left-joins other onto the DataFrame by the nearest key rather than an equal
+// key, as needed to enrich time series with the latest known value.
+//
+// Every left row is paired with at most one right row: the one whose on value is the
+// closest in the given direction, among the right rows whose by columns equal those of
+// the left row. Exact matches count in every direction. Left rows without such a right
+// row, or with a null on or by value, are kept with nulls in the right columns.
+//
+// other must be sorted ascending by on; the DataFrame itself may be in any order and its
+// row order is kept.
+//
+// Parameters:
+//   - other: the right DataFrame, sorted by on
+//   - on: the ordered key column, present in both DataFrames; numeric, datetime or string
+//   - by: columns, present in both DataFrames, that must match exactly; may be empty
+//   - direction: AsofBackward, AsofForward or AsofNearest; empty means AsofBackward
+//   - tolerance: the largest distance between matched on values, a number for numeric
+//     keys or a time.Duration for datetime keys; nil for no limit
+//
+// Returns:
+//   - A new DataFrame holding every left column followed by the right columns other than
+//     on and by. Other columns present on both sides are suffixed with "_x" and "_y".
+//   - An error if a column is missing, the direction or tolerance is invalid, other is
+//     not sorted by on or on values cannot be compared.
+//
+// Example:
+//
+//	// trades has columns time, ticker, qty; quotes has columns time, ticker, bid
+//	result, err := trades.MergeAsof(quotes, "time", []string{"ticker"}, AsofBackward, 2*time.Second)
+//	// time     | ticker | qty | bid
+//	// 10:00:01 | MSFT   | 100 | 51.95   <- quote of 10:00:00
+//	// 10:00:05 | MSFT   | 50  | NaN     <- last quote more than 2s older
+func (df *DataFrame) MergeAsof(other *DataFrame, on string, by []string, direction AsofDirection, tolerance any) (*DataFrame, error) {
+	if df == nil || other == nil {
+		return nil, errors.New("both DataFrames must be non-nil")
+	}
+	switch direction {
+	case "":
+		direction = AsofBackward
+	case AsofBackward, AsofForward, AsofNearest:
+	default:
+		return nil, fmt.Errorf("invalid asof direction: %s", direction)
+	}
+	limit, err := newAsofTolerance(tolerance)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := planMerge(df, other, MergeOptions{How: LeftMerge, On: append([]string{on}, by...)})
+	if err != nil {
+		return nil, err
+	}
+	leftOn, rightOn := plan.leftKeys[0], plan.rightKeys[0]
+	var leftBy, rightBy []any
+	if len(by) > 0 {
+		leftBy = rowKeys(plan.leftKeys[1:], false)
+		rightBy = rowKeys(plan.rightKeys[1:], false)
+	}
+
+	// Collect the right rows of every by group, checking that on is sorted
+	rightValues := make([]any, rightOn.Len())
+	groups := make(map[any][]int)
+	last := -1
+	for j := range rightValues {
+		rightValues[j] = normalizeKey(rightOn.At(j))
+		if rightValues[j] == nil {
+			continue
+		}
+		if last >= 0 {
+			order, ok := compareValues(rightValues[last], rightValues[j])
+			if !ok {
+				return nil, fmt.Errorf("cannot compare '%s' values %v and %v", on, rightValues[last], rightValues[j])
+			}
+			if order > 0 {
+				return nil, fmt.Errorf("the right DataFrame is not sorted by '%s': row %d comes before row %d", on, last, j)
+			}
+		}
+		last = j
+		var key any = true
+		if rightBy != nil {
+			if key = rightBy[j]; key == nil {
+				continue
+			}
+		}
+		groups[key] = append(groups[key], j)
+	}
+
+	var pairs mergePairs
+	for i := 0; i < leftOn.Len(); i++ {
+		var key any = true
+		if leftBy != nil {
+			key = leftBy[i]
+		}
+		match := -1
+		if value := normalizeKey(leftOn.At(i)); value != nil && key != nil {
+			match, err = asofMatch(value, groups[key], rightValues, direction, limit)
+			if err != nil {
+				return nil, fmt.Errorf("column '%s': %w", on, err)
+			}
+		}
+		pairs.add(i, match)
+	}
+	return plan.assemble(pairs)
+}
+
+// asofTolerance is the largest allowed distance of an asof match, measured as a number
+// for numeric keys or in nanoseconds for datetime keys.
+type asofTolerance struct {
+	set      bool
+	duration bool
+	limit    float64
+}
+
+// newAsofTolerance validates the tolerance argument of MergeAsof.
+func newAsofTolerance(tolerance any) (asofTolerance, error) {
+	var limit asofTolerance
+	switch t := tolerance.(type) {
+	case nil:
+		return limit, nil
+	case time.Duration:
+		limit = asofTolerance{set: true, duration: true, limit: float64(t)}
+	default:
+		switch v := normalizeValue(t).(type) {
+		case int64:
+			limit = asofTolerance{set: true, limit: float64(v)}
+		case float64:
+			limit = asofTolerance{set: true, limit: v}
+		default:
+			return limit, fmt.Errorf("invalid asof tolerance %v (%T): expected a number or a time.Duration", tolerance, tolerance)
+		}
+	}
+	if limit.limit < 0 || math.IsNaN(limit.limit) {
+		return limit, fmt.Errorf("asof tolerance must not be negative, got %v", tolerance)
+	}
+	return limit, nil
+}
+
+// asofMatch returns the row of rows, sorted by their value in values, that matches value
+// in the given direction within limit, or -1 if there is none.
+func asofMatch(value any, rows []int, values []any, direction AsofDirection, limit asofTolerance) (int, error) {
+	var err error
+	compare := func(k int) int {
+		order, ok := compareValues(values[rows[k]], value)
+		if !ok && err == nil {
+			err = fmt.Errorf("cannot compare %v (%T) and %v (%T)", values[rows[k]], values[rows[k]], value, value)
+		}
+		return order
+	}
+	// rows[:after] are at most value and rows[from:] at least value
+	after := sort.Search(len(rows), func(k int) bool { return compare(k) > 0 })
+	from := sort.Search(len(rows), func(k int) bool { return compare(k) >= 0 })
+	if err != nil {
+		return -1, err
+	}
+
+	backward, forward := -1, -1
+	if after > 0 && direction != AsofForward {
+		backward = rows[after-1]
+	}
+	if from < len(rows) && direction != AsofBackward {
+		forward = rows[from]
+	}
+	if backward < 0 && forward < 0 {
+		return -1, nil
+	}
+	if !limit.set && direction != AsofNearest {
+		return max(backward, forward), nil
+	}
+
+	best, bestDistance := -1, math.Inf(1)
+	for _, row := range []int{backward, forward} {
+		if row < 0 {
+			continue
+		}
+		distance, err := asofDistance(values[row], value, limit)
+		if err != nil {
+			return -1, err
+		}
+		if distance < bestDistance {
+			best, bestDistance = row, distance
+		}
+	}
+	if limit.set && bestDistance > limit.limit {
+		return -1, nil
+	}
+	return best, nil
+}
+
+// asofDistance returns the absolute distance between two key values, in nanoseconds for
+// times. It fails for keys that have no distance, such as strings, and for a tolerance
+// that does not fit the key type.
+func asofDistance(a, b any, limit asofTolerance) (float64, error) {
+	x, isTime := a.(time.Time)
+	if limit.set && isTime != limit.duration {
+		return 0, fmt.Errorf("tolerance does not fit %T keys", a)
+	}
+	if isTime {
+		if y, ok := b.(time.Time); ok {
+			return math.Abs(float64(x.Sub(y))), nil
+		}
+	}
+	toFloat := func(v any) (float64, bool) {
+		switch n := v.(type) {
+		case int64:
+			return float64(n), true
+		case float64:
+			return n, true
+		}
+		return 0, false
+	}
+	if x, ok := toFloat(a); ok {
+		if y, ok := toFloat(b); ok {
+			return math.Abs(x - y), nil
+		}
+	}
+	return 0, fmt.Errorf("cannot measure the distance between %v (%T) and %v (%T)", a, a, b, b)
+}
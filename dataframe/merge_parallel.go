@@ -0,0 +1,324 @@
+package dataframe
+
+import (
+	"hash/maphash"
+	"math"
+	"reflect"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// parallelMergeThreshold is the combined row count of both DataFrames from which
+// AutoEngine switches from HashEngine to ParallelHashEngine.
+const parallelMergeThreshold = 1 << 17
+
+// mergePartitionBits is the number of hash bits used to radix-partition the rows of a
+// parallel hash join, giving 1 << mergePartitionBits partitions.
+const mergePartitionBits = 6
+
+// parallelHashMerge pairs rows like the sequential perform*Merge functions, producing the
+// same pairs in the same order, but joins radix partitions of the rows concurrently.
+//
+// Both sides are partitioned by the hash of their keys, so matching rows always land in
+// the same partition. Each partition then builds a hash table over its build rows and
+// probes it with its probe rows in its own goroutine, recording how many output rows each
+// probe row produces. A prefix sum over those counts gives every probe row its place in
+// the output, which the partitions then fill concurrently.
+//
+// A right merge probes with the right rows instead, as performRightMerge does.
+func parallelHashMerge(how MergeHow, leftKeys, rightKeys []any) mergePairs {
+	if how == RightMerge {
+		pairs := partitionedJoin(LeftMerge, rightKeys, leftKeys)
+		pairs.left, pairs.right = pairs.right, pairs.left
+		return pairs
+	}
+	return partitionedJoin(how, leftKeys, rightKeys)
+}
+
+// partitionedJoin joins probe rows against build rows for an inner, left, full, semi or
+// anti merge. In the returned pairs, left holds probe rows and right holds build rows.
+func partitionedJoin(how MergeHow, probe, build []any) mergePairs {
+	seed := maphash.MakeSeed()
+	var probeParts, buildParts [][]int
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		probeParts = radixPartition(probe, seed)
+	}()
+	go func() {
+		defer wg.Done()
+		buildParts = radixPartition(build, seed)
+	}()
+	wg.Wait()
+
+	// Build and probe every partition, keeping the matches of each probe row
+	matches := make([][][]int, len(probeParts))
+	counts := make([]int, len(probe))
+	matchedBuild := make([]bool, len(build))
+	for p := range probeParts {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			table := make(map[any][]int, len(buildParts[p]))
+			for _, j := range buildParts[p] {
+				if build[j] != nil {
+					table[build[j]] = append(table[build[j]], j)
+				}
+			}
+			matches[p] = make([][]int, len(probeParts[p]))
+			for k, i := range probeParts[p] {
+				var found []int
+				if probe[i] != nil {
+					found = table[probe[i]]
+				}
+				matches[p][k] = found
+				counts[i] = joinOutputRows(how, len(found))
+				if how == FullMerge {
+					for _, j := range found {
+						matchedBuild[j] = true
+					}
+				}
+			}
+		}(p)
+	}
+	wg.Wait()
+
+	// Place the output rows of every probe row, in probe order
+	offsets := make([]int, len(probe))
+	total := 0
+	for i, count := range counts {
+		offsets[i] = total
+		total += count
+	}
+	pairs := mergePairs{left: make([]int, total), right: make([]int, total)}
+	for p := range probeParts {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for k, i := range probeParts[p] {
+				at, found := offsets[i], matches[p][k]
+				switch {
+				case how == AntiMerge:
+					if len(found) == 0 {
+						pairs.left[at], pairs.right[at] = i, -1
+					}
+				case how == SemiMerge:
+					if len(found) > 0 {
+						pairs.left[at], pairs.right[at] = i, found[0]
+					}
+				case len(found) == 0:
+					if how != InnerMerge {
+						pairs.left[at], pairs.right[at] = i, -1
+					}
+				default:
+					for n, j := range found {
+						pairs.left[at+n], pairs.right[at+n] = i, j
+					}
+				}
+			}
+		}(p)
+	}
+	wg.Wait()
+
+	if how == FullMerge {
+		for j, matched := range matchedBuild {
+			if !matched {
+				pairs.add(-1, j)
+			}
+		}
+	}
+	return pairs
+}
+
+// joinOutputRows returns the number of output rows a probe row with the given number of
+// matches produces under how.
+func joinOutputRows(how MergeHow, matches int) int {
+	switch how {
+	case InnerMerge:
+		return matches
+	case SemiMerge:
+		return min(matches, 1)
+	case AntiMerge:
+		if matches == 0 {
+			return 1
+		}
+		return 0
+	default:
+		return max(matches, 1)
+	}
+}
+
+// radixPartition splits the rows of keys into 1 << mergePartitionBits partitions by the
+// top bits of their key hash. Rows keep their ascending order within each partition.
+//
+// The rows are split into one chunk per CPU. Each chunk hashes its rows and counts them
+// per partition concurrently; a prefix sum over the (partition, chunk) counts then gives
+// every chunk its own range of each partition to scatter into, again concurrently.
+func radixPartition(keys []any, seed maphash.Seed) [][]int {
+	const partitions = 1 << mergePartitionBits
+	chunks := splitRows(len(keys))
+
+	ids := make([]uint8, len(keys))
+	histograms := make([][partitions]int, len(chunks))
+	parallelChunks(chunks, func(c, start, end int) {
+		for i := start; i < end; i++ {
+			ids[i] = uint8(hashKey(seed, keys[i]) >> (64 - mergePartitionBits))
+			histograms[c][ids[i]]++
+		}
+	})
+
+	// cursor[c][p] is where chunk c writes its next row of partition p
+	cursors := make([][partitions]int, len(chunks))
+	bounds := make([]int, partitions+1)
+	at := 0
+	for p := 0; p < partitions; p++ {
+		bounds[p] = at
+		for c := range chunks {
+			cursors[c][p] = at
+			at += histograms[c][p]
+		}
+	}
+	bounds[partitions] = at
+
+	rows := make([]int, len(keys))
+	parallelChunks(chunks, func(c, start, end int) {
+		for i := start; i < end; i++ {
+			rows[cursors[c][ids[i]]] = i
+			cursors[c][ids[i]]++
+		}
+	})
+
+	parts := make([][]int, partitions)
+	for p := range parts {
+		parts[p] = rows[bounds[p]:bounds[p+1]]
+	}
+	return parts
+}
+
+// splitRows splits n rows into at most one contiguous [start, end) range per CPU that
+// goroutines may run on (GOMAXPROCS).
+func splitRows(n int) [][2]int {
+	workers := max(1, min(runtime.GOMAXPROCS(0), n))
+	size := (n + workers - 1) / workers
+	var chunks [][2]int
+	for start := 0; start < n; start += size {
+		chunks = append(chunks, [2]int{start, min(start+size, n)})
+	}
+	return chunks
+}
+
+// parallelChunks calls fn concurrently for every chunk, passing its number and bounds,
+// and waits for all of them.
+func parallelChunks(chunks [][2]int, fn func(c, start, end int)) {
+	var wg sync.WaitGroup
+	for c, chunk := range chunks {
+		wg.Add(1)
+		go func(c, start, end int) {
+			defer wg.Done()
+			fn(c, start, end)
+		}(c, chunk[0], chunk[1])
+	}
+	wg.Wait()
+}
+
+// hashKey hashes a row key built by rowKeys. Keys that are equal under ==, as the map-based
+// engines compare them, hash alike: the common key types are hashed directly, and any
+// other comparable value through hashValue.
+func hashKey(seed maphash.Seed, key any) uint64 {
+	switch k := key.(type) {
+	case nil:
+		return 0
+	case int64:
+		return mixHash(uint64(k))
+	case string:
+		return maphash.String(seed, k)
+	case float64:
+		return hashFloat(k)
+	case bool:
+		if k {
+			return mixHash(1)
+		}
+		return mixHash(0)
+	case time.Time:
+		return mixHash(uint64(k.UnixNano()))
+	case nullKey:
+		return mixHash(2)
+	case compositeKey:
+		h := hashKey(seed, k.more)
+		for _, part := range k.parts {
+			h = mixHash(h + hashKey(seed, part))
+		}
+		return h
+	default:
+		return hashValue(seed, reflect.ValueOf(k))
+	}
+}
+
+// hashValue hashes a comparable value by walking it the way == compares it: structs and
+// arrays element by element, interfaces by their dynamic value and pointers and channels
+// by address. Other kinds cannot be keys and hash to 0.
+func hashValue(seed maphash.Seed, v reflect.Value) uint64 {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return mixHash(1)
+		}
+		return mixHash(0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return mixHash(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return mixHash(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return hashFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		return mixHash(hashFloat(real(c)) + hashFloat(imag(c)))
+	case reflect.String:
+		return maphash.String(seed, v.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return mixHash(uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		return hashValue(seed, v.Elem())
+	case reflect.Array:
+		var h uint64
+		for i := 0; i < v.Len(); i++ {
+			h = mixHash(h + hashValue(seed, v.Index(i)))
+		}
+		return h
+	case reflect.Struct:
+		var h uint64
+		for i := 0; i < v.NumField(); i++ {
+			// == ignores blank fields
+			if v.Type().Field(i).Name != "_" {
+				h = mixHash(h + hashValue(seed, v.Field(i)))
+			}
+		}
+		return h
+	default:
+		return 0
+	}
+}
+
+// hashFloat hashes a float so that -0.0 and 0.0, which are equal, hash alike.
+func hashFloat(f float64) uint64 {
+	if f == 0 {
+		return mixHash(0)
+	}
+	return mixHash(math.Float64bits(f))
+}
+
+// mixHash scrambles the bits of x (the splitmix64 finalizer), so that the top bits used
+// for partitioning depend on every input bit.
+func mixHash(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,172 @@
+package dataframe
+
+import "fmt"
+
+// sortedKeys holds the normalized key values of one side of a sort-merge join and the
+// rows that can take part in it, in row order.
+type sortedKeys struct {
+	// parts[c][i] is the normalized value of key column c at row i, nil for a null.
+	parts [][]any
+	// rows are the positions of the rows whose key can match, in ascending order.
+	rows []int
+}
+
+// newSortedKeys normalizes the key columns of one side of a sort-merge join and checks
+// that its rows are sorted by them. Rows with a null key part are left out unless
+// matchNulls is set, in which case nulls sort before every other value. side names the
+// DataFrame in errors.
+func newSortedKeys(cols []Series, matchNulls bool, side string) (*sortedKeys, error) {
+	n := cols[0].Len()
+	keys := &sortedKeys{parts: make([][]any, len(cols)), rows: make([]int, 0, n)}
+	for c, col := range cols {
+		keys.parts[c] = make([]any, n)
+		for i := 0; i < n; i++ {
+			keys.parts[c][i] = normalizeKey(col.At(i))
+		}
+	}
+
+rows:
+	for i := 0; i < n; i++ {
+		if !matchNulls {
+			for c := range cols {
+				if keys.parts[c][i] == nil {
+					continue rows
+				}
+			}
+		}
+		if k := len(keys.rows); k > 0 {
+			order, err := compareKeyRows(keys, keys.rows[k-1], keys, i)
+			if err != nil {
+				return nil, err
+			}
+			if order > 0 {
+				return nil, fmt.Errorf("the %s DataFrame is not sorted by its merge keys: row %d comes before row %d", side, keys.rows[k-1], i)
+			}
+		}
+		keys.rows = append(keys.rows, i)
+	}
+	return keys, nil
+}
+
+// compareKeyRows compares the key of row i of a with the key of row j of b, column by
+// column. A null part sorts before any value and equals another null.
+func compareKeyRows(a *sortedKeys, i int, b *sortedKeys, j int) (int, error) {
+	for c := range a.parts {
+		x, y := a.parts[c][i], b.parts[c][j]
+		switch {
+		case x == nil && y == nil:
+			continue
+		case x == nil:
+			return -1, nil
+		case y == nil:
+			return 1, nil
+		}
+		order, ok := compareValues(x, y)
+		if !ok {
+			return 0, fmt.Errorf("cannot compare merge keys %v (%T) and %v (%T)", x, x, y, y)
+		}
+		if order != 0 {
+			return order, nil
+		}
+	}
+	return 0, nil
+}
+
+// sortMerge pairs rows like the perform*Merge functions, producing the same pairs in the
+// same order, by walking both sides in key order instead of hashing their keys. Both
+// sides must already be sorted by their key columns; an error is returned otherwise.
+//
+// A right merge walks the sides with their roles swapped, as performRightMerge does.
+func sortMerge(how MergeHow, leftCols, rightCols []Series, matchNulls bool) (mergePairs, error) {
+	left, err := newSortedKeys(leftCols, matchNulls, "left")
+	if err != nil {
+		return mergePairs{}, err
+	}
+	right, err := newSortedKeys(rightCols, matchNulls, "right")
+	if err != nil {
+		return mergePairs{}, err
+	}
+	if how == RightMerge {
+		pairs, err := sortMergeRows(LeftMerge, right, left, rightCols[0].Len(), leftCols[0].Len())
+		pairs.left, pairs.right = pairs.right, pairs.left
+		return pairs, err
+	}
+	return sortMergeRows(how, left, right, leftCols[0].Len(), rightCols[0].Len())
+}
+
+// sortMergeRows joins the probe rows against the build rows for an inner, left, full,
+// semi or anti merge, given the row counts of both sides. In the returned pairs, left
+// holds probe rows and right holds build rows.
+func sortMergeRows(how MergeHow, probe, build *sortedKeys, probeLen, buildLen int) (mergePairs, error) {
+	// Walk both sides together, giving every probe row in a run of equal keys the run of
+	// build rows with that key
+	matches := make([][]int, probeLen)
+	i, j := 0, 0
+	for i < len(probe.rows) && j < len(build.rows) {
+		order, err := compareKeyRows(probe, probe.rows[i], build, build.rows[j])
+		if err != nil {
+			return mergePairs{}, err
+		}
+		switch {
+		case order < 0:
+			i++
+		case order > 0:
+			j++
+		default:
+			end := j + 1
+			for end < len(build.rows) {
+				order, err := compareKeyRows(build, build.rows[j], build, build.rows[end])
+				if err != nil {
+					return mergePairs{}, err
+				}
+				if order != 0 {
+					break
+				}
+				end++
+			}
+			for ; i < len(probe.rows); i++ {
+				order, err := compareKeyRows(probe, probe.rows[i], build, build.rows[j])
+				if err != nil {
+					return mergePairs{}, err
+				}
+				if order != 0 {
+					break
+				}
+				matches[probe.rows[i]] = build.rows[j:end]
+			}
+			j = end
+		}
+	}
+
+	var pairs mergePairs
+	matchedBuild := make([]bool, buildLen)
+	for row, found := range matches {
+		switch {
+		case how == AntiMerge:
+			if len(found) == 0 {
+				pairs.add(row, -1)
+			}
+		case how == SemiMerge:
+			if len(found) > 0 {
+				pairs.add(row, found[0])
+			}
+		case len(found) == 0:
+			if how != InnerMerge {
+				pairs.add(row, -1)
+			}
+		default:
+			for _, match := range found {
+				pairs.add(row, match)
+				matchedBuild[match] = true
+			}
+		}
+	}
+	if how == FullMerge {
+		for row, matched := range matchedBuild {
+			if !matched {
+				pairs.add(-1, row)
+			}
+		}
+	}
+	return pairs, nil
+}
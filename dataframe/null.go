@@ -0,0 +1,107 @@
+package dataframe
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// bitmap is a packed validity bitmap: bit i is set when row i holds a value and
+// cleared when row i is null.
+type bitmap []uint64
+
+// newBitmap creates a bitmap for n rows with every row marked valid.
+func newBitmap(n int) bitmap {
+	b := make(bitmap, (n+63)/64)
+	for i := range b {
+		b[i] = ^uint64(0)
+	}
+	return b
+}
+
+// get reports whether row i is valid.
+func (b bitmap) get(i int) bool {
+	return b[i>>6]&(1<<(uint(i)&63)) != 0
+}
+
+// set marks row i as valid or null.
+func (b bitmap) set(i int, valid bool) {
+	if valid {
+		b[i>>6] |= 1 << (uint(i) & 63)
+	} else {
+		b[i>>6] &^= 1 << (uint(i) & 63)
+	}
+}
+
+// countNulls returns the number of null rows among the first n rows.
+func (b bitmap) countNulls(n int) int {
+	valid := 0
+	full := n >> 6
+	for i := 0; i < full; i++ {
+		valid += bits.OnesCount64(b[i])
+	}
+	if rem := uint(n) & 63; rem != 0 {
+		valid += bits.OnesCount64(b[full] & (1<<rem - 1))
+	}
+	return n - valid
+}
+
+// IsNull returns a BoolCol that is true at every position where s is null.
+func IsNull(s Series) *BoolCol {
+	mask := make([]bool, s.Len())
+	for i := range mask {
+		mask[i] = s.IsNull(i)
+	}
+	return NewBoolCol(mask)
+}
+
+// NotNull returns a BoolCol that is true at every position where s holds a value.
+func NotNull(s Series) *BoolCol {
+	mask := make([]bool, s.Len())
+	for i := range mask {
+		mask[i] = !s.IsNull(i)
+	}
+	return NewBoolCol(mask)
+}
+
+// IsNull returns a DataFrame of the same shape whose columns are BoolCol masks that
+// are true wherever the original value is null.
+//
+// Returns:
+//   - *DataFrame: the null mask, with the same column names as df
+//   - error: nil if successful, otherwise an error if the DataFrame is nil
+//
+// Example:
+//
+//	df := &DataFrame{
+//	    Columns: []string{"A"},
+//	    Data:    []Series{NewSeries([]any{1, nil, 3})},
+//	}
+//	mask, err := df.IsNull()
+//	// mask.Data[0] holds [false true false]
+func (df *DataFrame) IsNull() (*DataFrame, error) {
+	return df.nullMask(IsNull)
+}
+
+// NotNull returns a DataFrame of the same shape whose columns are BoolCol masks that
+// are true wherever the original value is present. It is the inverse of IsNull.
+//
+// Returns:
+//   - *DataFrame: the not-null mask, with the same column names as df
+//   - error: nil if successful, otherwise an error if the DataFrame is nil
+func (df *DataFrame) NotNull() (*DataFrame, error) {
+	return df.nullMask(NotNull)
+}
+
+// nullMask applies a per-column mask function to every column of the DataFrame.
+func (df *DataFrame) nullMask(mask func(Series) *BoolCol) (*DataFrame, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+	columns := make([]string, len(df.Columns))
+	copy(columns, df.Columns)
+	data := make([]Series, len(df.Data))
+	for i, col := range df.Data {
+		data[i] = mask(col)
+	}
+	return &DataFrame{Columns: columns, Data: data, Index: df.Index}, nil
+}
@@ -0,0 +1,462 @@
+package dataframe
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// PivotMarginsName labels the row and the columns that PivotTable adds when margins are
+// requested.
+const PivotMarginsName = "All"
+
+const (
+	// DefaultMeltVarName names the column of Melt holding the names of the melted columns.
+	DefaultMeltVarName = "variable"
+	// DefaultMeltValueName names the column of Melt holding the melted values.
+	DefaultMeltValueName = "value"
+)
+
+// Pivot reshapes the DataFrame from long to wide without aggregating: every distinct
+// value of the index column becomes a row and every distinct value of the columns column
+// becomes a column, holding the value of the values column for that pair.
+//
+// Rows and columns are sorted by their key values, as by GroupBy, and rows with a null
+// in the index or columns column are left out. Columns are named after their key value;
+// cells without a matching row are null.
+//
+// Parameters:
+//   - index: the column whose values label the rows
+//   - columns: the column whose values become the new columns
+//   - values: the column holding the values of the cells
+//
+// Returns:
+//   - A new DataFrame with the index column first, followed by one column per distinct
+//     value of columns, with the dtype of values.
+//   - An error if a column is missing or repeated, or if two rows share an index and
+//     columns pair; use PivotTable to aggregate them instead.
+//
+// Example:
+//
+//	// df has columns date, city, temp
+//	wide, err := df.Pivot("date", "city", "temp")
+//	// date       | Berlin | Paris
+//	// 2024-01-01 | 3.5    | 7
+//	// 2024-01-02 | 4      | null
+func (df *DataFrame) Pivot(index, columns, values string) (*DataFrame, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+
+	df.Lock()
+	defer df.Unlock()
+
+	names := []string{index, columns, values}
+	if err := checkUniqueColumns(names); err != nil {
+		return nil, err
+	}
+	positions, err := df.columnPositions(names)
+	if err != nil {
+		return nil, err
+	}
+	layout, err := newPivotLayout(df, positions[:1], positions[1:2])
+	if err != nil {
+		return nil, err
+	}
+
+	value := layout.frame.Data[positions[2]]
+	out := layout.keyFrame()
+	for c, label := range layout.columnLabels() {
+		rows := make([]int, len(layout.rows))
+		for i := range rows {
+			cell := layout.cells[i*len(layout.cols)+c]
+			switch len(cell) {
+			case 0:
+				rows[i] = -1
+			case 1:
+				rows[i] = cell[0]
+			default:
+				return nil, fmt.Errorf("rows %d and %d share index %v and column %v; use PivotTable to aggregate them",
+					layout.kept[cell[0]], layout.kept[cell[1]], out.Data[0].At(i), label)
+			}
+		}
+		out.Columns = append(out.Columns, label)
+		out.Data = append(out.Data, value.Take(rows))
+	}
+	if err := checkUniqueColumns(out.Columns); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PivotTable reshapes the DataFrame from long to wide, aggregating the rows that share
+// an index and columns pair: every distinct combination of the index columns becomes a
+// row, every distinct combination of the columns columns becomes a column, and each cell
+// holds aggfunc applied to the values of its rows.
+//
+// Rows are grouped as by GroupBy: keys are sorted, rows with a null key are left out,
+// and value columns are aggregated concurrently. Cells without rows are null, or
+// fillValue when it is not nil. Int and float columns take fillValue after promotion, as
+// in Concat, so a fill of 0 keeps a float column a float column.
+//
+// A column is named after its key values, joined by "_" when there are several columns
+// keys. With several value columns, each name is prefixed by the value column, as in
+// "sales_Berlin".
+//
+// Parameters:
+//   - index: the columns whose values label the rows; at least one is required
+//   - columns: the columns whose values become the new columns; at least one is required
+//   - values: the columns to aggregate. Empty means every other column, or every other
+//     int and float column for aggregations that need numbers.
+//   - aggfunc: the aggregation applied to each cell, such as AggSum. The zero AggFunc
+//     means AggMean.
+//   - fillValue: the value of cells without rows, or nil to leave them null
+//   - margins: add a row and a column named PivotMarginsName ("All") that aggregate
+//     every column and every row of the table, and their total in the corner. Margins
+//     aggregate the original values, not the cells.
+//
+// Returns:
+//   - A new DataFrame with the index columns first, followed by the aggregated columns.
+//     With margins, the last row holds "All" in the first index column and nulls in the
+//     others. A first index column that does not hold strings, such as an int or
+//     datetime column, cannot hold the label and becomes an object column.
+//   - An error if a column is missing or repeated, a key is not given, aggfunc fails, or
+//     two result columns get the same name.
+//
+// Example:
+//
+//	// df has columns region, quarter, sales
+//	table, err := df.PivotTable([]string{"region"}, []string{"quarter"}, []string{"sales"}, AggSum, 0, true)
+//	// region | Q1  | Q2  | All
+//	// East   | 120 | 80  | 200
+//	// West   | 0   | 95  | 95
+//	// All    | 120 | 175 | 295
+func (df *DataFrame) PivotTable(index, columns, values []string, aggfunc AggFunc, fillValue any, margins bool) (*DataFrame, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+	if len(index) == 0 || len(columns) == 0 {
+		return nil, errors.New("at least one index and one columns key is required")
+	}
+	if aggfunc.Name == "" && aggfunc.kernel == nil && aggfunc.err == nil {
+		aggfunc = AggMean
+	}
+
+	df.Lock()
+	defer df.Unlock()
+
+	keys := append(append([]string(nil), index...), columns...)
+	keyPos, err := df.columnPositions(keys)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		for pos, name := range df.Columns {
+			if !slices.Contains(keyPos, pos) && (!aggfunc.numeric || isNumeric(df.Data[pos])) {
+				values = append(values, name)
+			}
+		}
+		if len(values) == 0 {
+			return nil, errors.New("no columns left to aggregate")
+		}
+	}
+	if err := checkUniqueColumns(append(keys, values...)); err != nil {
+		return nil, err
+	}
+	valuePos, err := df.columnPositions(values)
+	if err != nil {
+		return nil, err
+	}
+	layout, err := newPivotLayout(df, keyPos[:len(index)], keyPos[len(index):])
+	if err != nil {
+		return nil, err
+	}
+	nRows, nCols := len(layout.rows), len(layout.cols)
+
+	// groups holds the rows of every non-empty cell, then with margins those of every
+	// table column, every table row and the whole table
+	var groups [][]int
+	cellGroup := make([]int, len(layout.cells))
+	for k, cell := range layout.cells {
+		cellGroup[k] = -1
+		if len(cell) > 0 {
+			cellGroup[k] = len(groups)
+			groups = append(groups, cell)
+		}
+	}
+	cells := len(groups)
+	if margins {
+		all := make([]int, layout.frame.rowCount())
+		for r := range all {
+			all[r] = r
+		}
+		groups = append(append(append(groups, layout.cols...), layout.rows...), all)
+	}
+
+	labels := layout.columnLabels()
+	if margins {
+		labels = append(labels, PivotMarginsName)
+	}
+	tableRows := nRows
+	if margins {
+		tableRows++
+	}
+	results := make([][]Series, len(valuePos))
+	errs := make([]error, len(valuePos))
+	var wg sync.WaitGroup
+	for v, pos := range valuePos {
+		wg.Add(1)
+		go func(v int, col Series) {
+			defer wg.Done()
+			result, err := aggfunc.apply(col, groups)
+			if err != nil {
+				errs[v] = fmt.Errorf("column %s: %w", values[v], err)
+				return
+			}
+			results[v] = make([]Series, len(labels))
+			for c := range labels {
+				rows := make([]int, tableRows)
+				for i := 0; i < nRows; i++ {
+					if c < nCols {
+						rows[i] = cellGroup[i*nCols+c]
+					} else {
+						rows[i] = cells + nCols + i
+					}
+				}
+				if margins {
+					rows[nRows] = cells + nCols + nRows
+					if c < nCols {
+						rows[nRows] = cells + c
+					}
+				}
+				results[v][c] = fillNulls(result.Take(rows), fillValue)
+			}
+		}(v, layout.frame.Data[pos])
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	out := layout.keyFrame()
+	if margins {
+		for k, key := range out.Data {
+			total := nullSeriesLike(key, 1)
+			if k == 0 {
+				total = NewStringCol([]string{PivotMarginsName})
+			}
+			out.Data[k] = concatSeries([]Series{key, total})
+		}
+	}
+	for v, name := range values {
+		for c, label := range labels {
+			if len(values) > 1 {
+				label = name + "_" + label
+			}
+			out.Columns = append(out.Columns, label)
+			out.Data = append(out.Data, results[v][c])
+		}
+	}
+	if err := checkUniqueColumns(out.Columns); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Melt reshapes the DataFrame from wide to long, the reverse of Pivot: every value
+// column is unstacked into rows holding the id columns, the name of the value column and
+// its value.
+//
+// The rows of the first value column come first, then those of the next one, each in the
+// original row order. The result has a fresh RangeIndex. Values of int and float columns
+// combine into a float column; other mixed types give an object column, as in Concat.
+//
+// Parameters:
+//   - idVars: the columns repeated on every row of the result
+//   - valueVars: the columns to unstack. Empty means every column not in idVars.
+//   - varName: the name of the column holding the names of the value columns; empty
+//     means DefaultMeltVarName ("variable")
+//   - valueName: the name of the column holding the values; empty means
+//     DefaultMeltValueName ("value")
+//
+// Returns:
+//   - A new DataFrame with the id columns, then varName and valueName, holding one row
+//     per original row and value column.
+//   - An error if a column is missing or repeated, or varName or valueName collide with
+//     an id column.
+//
+// Example:
+//
+//	// wide has columns date, Berlin, Paris
+//	long, err := wide.Melt([]string{"date"}, nil, "city", "temp")
+//	// date       | city   | temp
+//	// 2024-01-01 | Berlin | 3.5
+//	// 2024-01-02 | Berlin | 4
+//	// 2024-01-01 | Paris  | 7
+//	// 2024-01-02 | Paris  | null
+func (df *DataFrame) Melt(idVars, valueVars []string, varName, valueName string) (*DataFrame, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+	if varName == "" {
+		varName = DefaultMeltVarName
+	}
+	if valueName == "" {
+		valueName = DefaultMeltValueName
+	}
+
+	df.Lock()
+	defer df.Unlock()
+
+	idPos, err := df.columnPositions(idVars)
+	if err != nil {
+		return nil, err
+	}
+	if len(valueVars) == 0 {
+		for pos, name := range df.Columns {
+			if !slices.Contains(idPos, pos) {
+				valueVars = append(valueVars, name)
+			}
+		}
+	}
+	if err := checkUniqueColumns(append(append([]string(nil), idVars...), valueVars...)); err != nil {
+		return nil, err
+	}
+	valuePos, err := df.columnPositions(valueVars)
+	if err != nil {
+		return nil, err
+	}
+
+	n := df.rowCount()
+	repeated := make([]int, 0, n*len(valuePos))
+	variables := make([]string, 0, n*len(valuePos))
+	parts := make([]Series, len(valuePos))
+	for v, pos := range valuePos {
+		for r := 0; r < n; r++ {
+			repeated = append(repeated, r)
+			variables = append(variables, valueVars[v])
+		}
+		parts[v] = df.Data[pos]
+	}
+
+	out := &DataFrame{}
+	for k, pos := range idPos {
+		out.Columns = append(out.Columns, idVars[k])
+		out.Data = append(out.Data, df.Data[pos].Take(repeated))
+	}
+	out.Columns = append(out.Columns, varName, valueName)
+	out.Data = append(out.Data, NewStringCol(variables), concatSeries(parts))
+	if err := checkUniqueColumns(out.Columns); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// pivotLayout places the rows of a DataFrame in the cells of a pivot table whose rows are
+// the groups of the index keys and whose columns are the groups of the columns keys, both
+// found and sorted as by GroupBy. Rows with a null key are dropped first.
+type pivotLayout struct {
+	frame     *DataFrame // the rows of the DataFrame without a null key
+	kept      []int      // position in the DataFrame of every row of frame
+	indexPos  []int
+	columnPos []int
+	rows      [][]int // rows of frame in every table row
+	cols      [][]int // rows of frame in every table column
+	cells     [][]int // rows of frame in the cell of table row i and column c, at i*len(cols)+c
+}
+
+// newPivotLayout lays out df with the index keys at indexPos and the columns keys at
+// columnPos. It fails if a key holds a value that cannot be hashed.
+func newPivotLayout(df *DataFrame, indexPos, columnPos []int) (*pivotLayout, error) {
+	keyPos := append(append([]int(nil), indexPos...), columnPos...)
+	for _, pos := range keyPos {
+		if err := checkHashable(df.Data[pos]); err != nil {
+			return nil, fmt.Errorf("key '%s': %w", df.Columns[pos], err)
+		}
+	}
+	var kept []int
+	for r := 0; r < df.rowCount(); r++ {
+		if !slices.ContainsFunc(keyPos, func(pos int) bool { return df.Data[pos].IsNull(r) }) {
+			kept = append(kept, r)
+		}
+	}
+	l := &pivotLayout{
+		frame:     df.takeRows(kept),
+		kept:      kept,
+		indexPos:  indexPos,
+		columnPos: columnPos,
+	}
+	l.rows = groupRows(l.keyCols(indexPos))
+	l.cols = groupRows(l.keyCols(columnPos))
+
+	colOf := make([]int, len(kept))
+	for c, rows := range l.cols {
+		for _, r := range rows {
+			colOf[r] = c
+		}
+	}
+	l.cells = make([][]int, len(l.rows)*len(l.cols))
+	for i, rows := range l.rows {
+		for _, r := range rows {
+			cell := i*len(l.cols) + colOf[r]
+			l.cells[cell] = append(l.cells[cell], r)
+		}
+	}
+	return l, nil
+}
+
+// keyCols returns the columns of frame at positions.
+func (l *pivotLayout) keyCols(positions []int) []Series {
+	cols := make([]Series, len(positions))
+	for i, pos := range positions {
+		cols[i] = l.frame.Data[pos]
+	}
+	return cols
+}
+
+// keyFrame returns a DataFrame holding the index keys of every table row.
+func (l *pivotLayout) keyFrame() *DataFrame {
+	firstRows := make([]int, len(l.rows))
+	for i, rows := range l.rows {
+		firstRows[i] = rows[0]
+	}
+	out := &DataFrame{}
+	for _, pos := range l.indexPos {
+		out.Columns = append(out.Columns, l.frame.Columns[pos])
+		out.Data = append(out.Data, l.frame.Data[pos].Take(firstRows))
+	}
+	return out
+}
+
+// columnLabels names every table column after its columns keys, joined by "_".
+func (l *pivotLayout) columnLabels() []string {
+	labels := make([]string, len(l.cols))
+	parts := make([]string, len(l.columnPos))
+	for c, rows := range l.cols {
+		for k, pos := range l.columnPos {
+			parts[k] = fmt.Sprint(l.frame.Data[pos].At(rows[0]))
+		}
+		labels[c] = strings.Join(parts, "_")
+	}
+	return labels
+}
+
+// fillNulls returns col with its nulls replaced by value, or col itself if value is nil
+// or col has no nulls. The result takes the type that holds both, as in concatSeries.
+func fillNulls(col Series, value any) Series {
+	if value == nil || col.NullCount() == 0 {
+		return col
+	}
+	n := col.Len()
+	filled := concatSeries([]Series{col, NewSeries([]any{value})})
+	rows := make([]int, n)
+	for i := range rows {
+		rows[i] = i
+		if col.IsNull(i) {
+			rows[i] = n
+		}
+	}
+	return filled.Take(rows)
+}
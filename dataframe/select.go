@@ -0,0 +1,289 @@
+package dataframe
+
+import (
+	"errors"
+	"fmt"
+	"gpandas/utils/collection"
+	"regexp"
+	"strings"
+)
+
+// Select returns a new DataFrame holding only the given columns, in the given order.
+//
+// Parameters:
+//   - cols: names of the columns to keep. Every name must be present in the DataFrame and
+//     may appear only once.
+//
+// Returns:
+//   - A new DataFrame whose columns are copies of the selected columns, so modifying it
+//     never affects the original DataFrame.
+//   - An error if no column is given, a column is not present or a column is repeated.
+//
+// Example:
+//
+//	// df has columns ID, Name, Age
+//	people, err := df.Select("Name", "ID")
+//	// Name    | ID
+//	// Alice   | 1
+//	// Bob     | 2
+func (df *DataFrame) Select(cols ...string) (*DataFrame, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+	if len(cols) == 0 {
+		return nil, errors.New("at least one column name is required")
+	}
+
+	df.Lock()
+	defer df.Unlock()
+
+	if err := checkUniqueColumns(cols); err != nil {
+		return nil, err
+	}
+	positions, err := df.columnPositions(cols)
+	if err != nil {
+		return nil, err
+	}
+	return df.takeColumns(positions), nil
+}
+
+// Drop returns a new DataFrame without the given columns. The remaining columns keep
+// their order.
+//
+// Parameters:
+//   - cols: names of the columns to remove. Every name must be present in the DataFrame.
+//
+// Returns:
+//   - A new DataFrame whose columns are copies of the remaining columns.
+//   - An error if no column is given or a column is not present.
+//
+// Example:
+//
+//	// df has columns ID, Name, Age
+//	withoutAge, err := df.Drop("Age")
+//	// ID | Name
+func (df *DataFrame) Drop(cols ...string) (*DataFrame, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+	if len(cols) == 0 {
+		return nil, errors.New("at least one column name is required")
+	}
+
+	df.Lock()
+	defer df.Unlock()
+
+	if _, err := df.columnPositions(cols); err != nil {
+		return nil, err
+	}
+	dropped, err := collection.ToSet(cols)
+	if err != nil {
+		return nil, err
+	}
+
+	var positions []int
+	for i, name := range df.Columns {
+		if !dropped.Has(name) {
+			positions = append(positions, i)
+		}
+	}
+	return df.takeColumns(positions), nil
+}
+
+// Reorder returns a new DataFrame with the given columns moved to the front, in the given
+// order, followed by the remaining columns in their current order.
+//
+// Parameters:
+//   - cols: names of the columns to move to the front. Every name must be present in the
+//     DataFrame and may appear only once. Listing every column gives a full reordering.
+//
+// Returns:
+//   - A new DataFrame whose columns are copies of the original columns.
+//   - An error if no column is given, a column is not present or a column is repeated.
+//
+// Example:
+//
+//	// df has columns ID, Name, Age
+//	reordered, err := df.Reorder("Age", "Name")
+//	// Age | Name | ID
+func (df *DataFrame) Reorder(cols ...string) (*DataFrame, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+	if len(cols) == 0 {
+		return nil, errors.New("at least one column name is required")
+	}
+
+	df.Lock()
+	defer df.Unlock()
+
+	if err := checkUniqueColumns(cols); err != nil {
+		return nil, err
+	}
+	positions, err := df.columnPositions(cols)
+	if err != nil {
+		return nil, err
+	}
+	moved, err := collection.ToSet(cols)
+	if err != nil {
+		return nil, err
+	}
+	for i, name := range df.Columns {
+		if !moved.Has(name) {
+			positions = append(positions, i)
+		}
+	}
+	return df.takeColumns(positions), nil
+}
+
+// SelectRegex returns a new DataFrame holding the columns whose names match pattern, in
+// their current order. A pattern that matches no column gives a DataFrame without columns.
+//
+// Parameters:
+//   - pattern: a regular expression in the syntax of the regexp package. It matches
+//     anywhere in the name unless anchored with ^ and $.
+//
+// Returns:
+//   - A new DataFrame whose columns are copies of the matching columns.
+//   - An error if pattern does not compile.
+//
+// Example:
+//
+//	// df has columns id, sales_2023, sales_2024, region
+//	sales, err := df.SelectRegex(`^sales_\d{4}$`)
+//	// sales_2023 | sales_2024
+func (df *DataFrame) SelectRegex(pattern string) (*DataFrame, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid column pattern: %w", err)
+	}
+
+	df.Lock()
+	defer df.Unlock()
+
+	return df.takeColumns(df.matchingColumns(re.MatchString)), nil
+}
+
+// SelectPrefix returns a new DataFrame holding the columns whose names start with prefix,
+// in their current order. A prefix that matches no column gives a DataFrame without columns.
+//
+// Example:
+//
+//	// df has columns id, sales_2023, sales_2024, region
+//	sales, err := df.SelectPrefix("sales_")
+//	// sales_2023 | sales_2024
+func (df *DataFrame) SelectPrefix(prefix string) (*DataFrame, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+
+	df.Lock()
+	defer df.Unlock()
+
+	return df.takeColumns(df.matchingColumns(func(name string) bool {
+		return strings.HasPrefix(name, prefix)
+	})), nil
+}
+
+// Col returns the Series holding the column with the given name.
+//
+// The Series is shared with the DataFrame, not copied. Assert it to its concrete type
+// (for example *IntCol) for typed access, or use ColAs.
+//
+// Returns:
+//   - The Series of the column.
+//   - An error if the column is not present.
+//
+// Example:
+//
+//	age, err := df.Col("Age")
+//	fmt.Println(age.DType(), age.Len())
+func (df *DataFrame) Col(name string) (Series, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+
+	df.Lock()
+	defer df.Unlock()
+
+	positions, err := df.columnPositions([]string{name})
+	if err != nil {
+		return nil, err
+	}
+	return df.Data[positions[0]], nil
+}
+
+// ColAs returns the column with the given name as a TypeColumn of T, such as *FloatCol
+// (T = float64) or *StringCol (T = string). Like Col, the column is shared with the
+// DataFrame.
+//
+// ColAs is a function rather than a method because Go methods cannot take type parameters.
+//
+// Returns:
+//   - The typed column.
+//   - An error if the column is not present or does not hold values of type T.
+//
+// Example:
+//
+//	ages, err := ColAs[int64](df, "Age")
+//	total := int64(0)
+//	for _, age := range ages.Values() {
+//	    total += age
+//	}
+func ColAs[T comparable](df *DataFrame, name string) (*TypeColumn[T], error) {
+	series, err := df.Col(name)
+	if err != nil {
+		return nil, err
+	}
+	typed, ok := series.(*TypeColumn[T])
+	if !ok {
+		var zero T
+		return nil, fmt.Errorf("column '%s' has dtype %s, not %T", name, series.DType(), zero)
+	}
+	return typed, nil
+}
+
+// checkUniqueColumns returns an error naming the first column that appears more than once
+// in cols.
+func checkUniqueColumns(cols []string) error {
+	seen, err := collection.NewSet[string](len(cols))
+	if err != nil {
+		return err
+	}
+	for _, col := range cols {
+		if seen.Has(col) {
+			return errors.New("the column '" + col + "' is specified more than once")
+		}
+		seen.Add(col)
+	}
+	return nil
+}
+
+// matchingColumns returns the positions of the columns whose names satisfy match.
+func (df *DataFrame) matchingColumns(match func(string) bool) []int {
+	var positions []int
+	for i, name := range df.Columns {
+		if match(name) {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
+// takeColumns returns a new DataFrame holding copies of the columns at the given positions.
+// The index is shared with df.
+func (df *DataFrame) takeColumns(positions []int) *DataFrame {
+	out := &DataFrame{
+		Columns: make([]string, len(positions)),
+		Data:    make([]Series, len(positions)),
+		Index:   df.Index,
+	}
+	for i, pos := range positions {
+		out.Columns[i] = df.Columns[pos]
+		out.Data[i] = df.Data[pos].Copy()
+	}
+	return out
+}
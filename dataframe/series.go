@@ -0,0 +1,403 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// DType identifies the type of the values stored in a Series.
+type DType string
+
+const (
+	FloatType    DType = "float64"
+	IntType      DType = "int64"
+	StringType   DType = "string"
+	BoolType     DType = "bool"
+	DateTimeType DType = "datetime"
+	ObjectType   DType = "object"
+)
+
+// Series is a single column of a DataFrame.
+//
+// Every implementation stores its values contiguously in a typed slice, so numeric
+// columns are plain []float64 / []int64 without per-value boxing. Missing values are
+// tracked in a validity bitmap next to the values rather than stored as nil. The boxed
+// accessor At exists for generic code such as printing; typed code should assert the
+// concrete column type (for example *FloatCol) and use Values or Value instead.
+type Series interface {
+	// Len returns the number of values in the Series.
+	Len() int
+	// DType returns the type of the values stored in the Series.
+	DType() DType
+	// At returns the value at position i boxed as any, or nil if it is null.
+	At(i int) any
+	// IsNull reports whether the value at position i is null.
+	IsNull(i int) bool
+	// NullCount returns the number of null values in the Series.
+	NullCount() int
+	// Take returns a new Series holding the values at the given positions, in order.
+	// A negative position produces a null value.
+	Take(indices []int) Series
+	// Copy returns a deep copy of the Series.
+	Copy() Series
+	// Gt returns a Mask that is true where the value is greater than v, a scalar or a
+	// Series of the same length. Null values never match.
+	Gt(v any) Mask
+	// Ge returns a Mask that is true where the value is greater than or equal to v.
+	Ge(v any) Mask
+	// Lt returns a Mask that is true where the value is less than v.
+	Lt(v any) Mask
+	// Le returns a Mask that is true where the value is less than or equal to v.
+	Le(v any) Mask
+	// Eq returns a Mask that is true where the value equals v.
+	Eq(v any) Mask
+	// Ne returns a Mask that is true where the value is present and differs from v.
+	Ne(v any) Mask
+}
+
+// TypeColumn is a Series backed by a contiguous slice of a comparable type T.
+//
+// FloatCol, IntCol, StringCol, BoolCol, DateTimeCol and ObjectCol are the instantiations used by
+// gpandas; use the matching constructor (NewFloatCol, NewIntCol, ...) to build one.
+//
+// Nulls are tracked in a validity bitmap. A column without nulls carries no bitmap at
+// all, and the slot of a null value in the backing slice holds the zero value of T.
+type TypeColumn[T comparable] struct {
+	data  []T
+	valid bitmap // nil when every value is present
+}
+
+// FloatCol is a Series of float64 values.
+type FloatCol = TypeColumn[float64]
+
+// IntCol is a Series of int64 values.
+type IntCol = TypeColumn[int64]
+
+// StringCol is a Series of string values.
+type StringCol = TypeColumn[string]
+
+// BoolCol is a Series of bool values.
+type BoolCol = TypeColumn[bool]
+
+// DateTimeCol is a Series of time.Time values.
+type DateTimeCol = TypeColumn[time.Time]
+
+// ObjectCol is a Series of boxed values of any type. It is the fallback for columns
+// whose values do not share a single supported type.
+type ObjectCol = TypeColumn[any]
+
+// Column represents a column slice of any type. It is the boxed form accepted by
+// NewSeries and NewSeriesAs.
+type Column []any
+
+// NewTypeColumn creates a TypeColumn that takes ownership of values.
+func NewTypeColumn[T comparable](values []T) *TypeColumn[T] {
+	return &TypeColumn[T]{data: values}
+}
+
+// NewFloatCol creates a FloatCol that takes ownership of values.
+func NewFloatCol(values []float64) *FloatCol { return NewTypeColumn(values) }
+
+// NewIntCol creates an IntCol that takes ownership of values.
+func NewIntCol(values []int64) *IntCol { return NewTypeColumn(values) }
+
+// NewStringCol creates a StringCol that takes ownership of values.
+func NewStringCol(values []string) *StringCol { return NewTypeColumn(values) }
+
+// NewBoolCol creates a BoolCol that takes ownership of values.
+func NewBoolCol(values []bool) *BoolCol { return NewTypeColumn(values) }
+
+// NewDateTimeCol creates a DateTimeCol that takes ownership of values.
+func NewDateTimeCol(values []time.Time) *DateTimeCol { return NewTypeColumn(values) }
+
+// NewObjectCol creates an ObjectCol that takes ownership of values.
+func NewObjectCol(values []any) *ObjectCol { return NewTypeColumn(values) }
+
+// Len returns the number of values in the column.
+func (c *TypeColumn[T]) Len() int {
+	return len(c.data)
+}
+
+// DType returns the type of the values stored in the column.
+func (c *TypeColumn[T]) DType() DType {
+	var zero T
+	switch any(zero).(type) {
+	case float64:
+		return FloatType
+	case int64:
+		return IntType
+	case string:
+		return StringType
+	case bool:
+		return BoolType
+	case time.Time:
+		return DateTimeType
+	default:
+		return ObjectType
+	}
+}
+
+// At returns the value at position i boxed as any, or nil if it is null.
+func (c *TypeColumn[T]) At(i int) any {
+	if c.IsNull(i) {
+		return nil
+	}
+	return c.data[i]
+}
+
+// Value returns the value at position i without boxing. A null value is returned as
+// the zero value of T; use IsNull to tell the two apart.
+func (c *TypeColumn[T]) Value(i int) T {
+	return c.data[i]
+}
+
+// Values returns the backing slice of the column. The slice is shared with the column,
+// so callers must not modify it unless they own the column. Null positions hold the
+// zero value of T.
+func (c *TypeColumn[T]) Values() []T {
+	return c.data
+}
+
+// IsNull reports whether the value at position i is null.
+func (c *TypeColumn[T]) IsNull(i int) bool {
+	return c.valid != nil && !c.valid.get(i)
+}
+
+// NullCount returns the number of null values in the column.
+func (c *TypeColumn[T]) NullCount() int {
+	if c.valid == nil {
+		return 0
+	}
+	return c.valid.countNulls(len(c.data))
+}
+
+// SetNull marks the value at position i as null.
+func (c *TypeColumn[T]) SetNull(i int) {
+	if c.valid == nil {
+		c.valid = newBitmap(len(c.data))
+	}
+	var zero T
+	c.data[i] = zero
+	c.valid.set(i, false)
+}
+
+// Take returns a new column holding the values at the given positions, in order.
+// A negative position produces a null value.
+func (c *TypeColumn[T]) Take(indices []int) Series {
+	out := &TypeColumn[T]{data: make([]T, len(indices))}
+	for i, idx := range indices {
+		if idx < 0 || c.IsNull(idx) {
+			out.SetNull(i)
+			continue
+		}
+		out.data[i] = c.data[idx]
+	}
+	return out
+}
+
+// Copy returns a deep copy of the column.
+func (c *TypeColumn[T]) Copy() Series {
+	out := &TypeColumn[T]{data: make([]T, len(c.data))}
+	copy(out.data, c.data)
+	if c.valid != nil {
+		out.valid = make(bitmap, len(c.valid))
+		copy(out.valid, c.valid)
+	}
+	return out
+}
+
+// formatValue renders a single non-null value as text for String and CSV output.
+// Times without a clock component are written as dates, other times as RFC 3339.
+func formatValue(v any) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case time.Time:
+		if x.Hour() == 0 && x.Minute() == 0 && x.Second() == 0 && x.Nanosecond() == 0 && x.Location() == time.UTC {
+			return x.Format(time.DateOnly)
+		}
+		return x.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// normalizeValue converts Go scalar values to the widths used by Series:
+// signed and unsigned integers become int64, float32 becomes float64 and []byte
+// becomes string. Other values are returned unchanged, including unsigned integers
+// above math.MaxInt64, which would wrap around as int64; NewSeries stores those in an
+// ObjectCol.
+func normalizeValue(v any) any {
+	switch x := v.(type) {
+	case int:
+		return int64(x)
+	case int8:
+		return int64(x)
+	case int16:
+		return int64(x)
+	case int32:
+		return int64(x)
+	case uint:
+		if uint64(x) > math.MaxInt64 {
+			return v
+		}
+		return int64(x)
+	case uint8:
+		return int64(x)
+	case uint16:
+		return int64(x)
+	case uint32:
+		return int64(x)
+	case uint64:
+		if x > math.MaxInt64 {
+			return v
+		}
+		return int64(x)
+	case float32:
+		return float64(x)
+	case []byte:
+		return string(x)
+	default:
+		return v
+	}
+}
+
+// checkHashable returns an error for the first value of an object column that cannot be
+// used as a map key, such as a slice or a map, so that the hash tables built over the
+// column never panic. Columns of other types only hold hashable values.
+func checkHashable(col Series) error {
+	return checkHashableAs(col, func(v any) any { return v })
+}
+
+// checkHashableAs checks that key(v) is hashable for every value v of an object column.
+func checkHashableAs(col Series, key func(v any) any) error {
+	values, ok := col.(*ObjectCol)
+	if !ok {
+		return nil
+	}
+	for i, v := range values.data {
+		if !hashable(key(v)) {
+			return fmt.Errorf("the value at row %d has the unhashable type %T", i, v)
+		}
+	}
+	return nil
+}
+
+// hashable reports whether v can be used as a map key. reflect.Value.Comparable also
+// looks at the dynamic values held in interface fields, which reflect.Type.Comparable
+// cannot.
+func hashable(v any) bool {
+	return v == nil || reflect.ValueOf(v).Comparable()
+}
+
+// NewSeries builds a Series from boxed values, choosing the narrowest column type that
+// holds every value.
+//
+// Values are first normalized (int -> int64, float32 -> float64, []byte -> string).
+// nil values become nulls and do not take part in choosing the type. If every other
+// value then shares one of the supported types the matching typed column is returned,
+// otherwise the values are kept in an ObjectCol.
+//
+// Example:
+//
+//	s := NewSeries([]any{1, 2, 3})         // *IntCol
+//	s := NewSeries([]any{1.5, nil, 2.5})   // *FloatCol with a null at position 1
+//	s := NewSeries([]any{"a", 2, true})    // *ObjectCol
+func NewSeries(values []any) Series {
+	normalized := make([]any, len(values))
+	dtype := DType("")
+	for i, v := range values {
+		normalized[i] = normalizeValue(v)
+		if normalized[i] == nil {
+			continue
+		}
+		vt := dtypeOf(normalized[i])
+		if dtype == "" {
+			dtype = vt
+		} else if dtype != vt {
+			dtype = ObjectType
+		}
+	}
+	if dtype == "" {
+		dtype = ObjectType
+	}
+	s, err := NewSeriesAs(dtype, normalized)
+	if err != nil {
+		// dtype was derived from the values, so conversion cannot fail
+		return NewObjectCol(normalized)
+	}
+	return s
+}
+
+// dtypeOf returns the DType that holds a single normalized value.
+func dtypeOf(v any) DType {
+	switch v.(type) {
+	case float64:
+		return FloatType
+	case int64:
+		return IntType
+	case string:
+		return StringType
+	case bool:
+		return BoolType
+	case time.Time:
+		return DateTimeType
+	default:
+		return ObjectType
+	}
+}
+
+// NewSeriesAs builds a Series of the given DType from boxed values.
+//
+// Values are normalized as in NewSeries and must then match dtype exactly; ObjectType
+// accepts any value. nil values are stored as nulls for every dtype.
+//
+// Returns:
+//   - Series: the typed column holding the values
+//   - error: nil if successful, otherwise an error naming the first value that does not match dtype
+func NewSeriesAs(dtype DType, values []any) (Series, error) {
+	switch dtype {
+	case FloatType:
+		return convertColumn[float64](dtype, values)
+	case IntType:
+		return convertColumn[int64](dtype, values)
+	case StringType:
+		return convertColumn[string](dtype, values)
+	case BoolType:
+		return convertColumn[bool](dtype, values)
+	case DateTimeType:
+		return convertColumn[time.Time](dtype, values)
+	case ObjectType:
+		out := NewObjectCol(make([]any, len(values)))
+		for i, v := range values {
+			if v == nil {
+				out.SetNull(i)
+				continue
+			}
+			out.data[i] = normalizeValue(v)
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("unsupported dtype: %s", dtype)
+	}
+}
+
+// convertColumn asserts every normalized value to T and collects them into a TypeColumn,
+// storing nil values as nulls.
+func convertColumn[T comparable](dtype DType, values []any) (Series, error) {
+	out := NewTypeColumn(make([]T, len(values)))
+	for i, v := range values {
+		if v == nil {
+			out.SetNull(i)
+			continue
+		}
+		typed, ok := normalizeValue(v).(T)
+		if !ok {
+			return nil, fmt.Errorf("type mismatch at row %d: expected %s, got %T", i, dtype, v)
+		}
+		out.data[i] = typed
+	}
+	return out, nil
+}
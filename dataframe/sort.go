@@ -0,0 +1,329 @@
+package dataframe
+
+import (
+	"cmp"
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// sortParallelThreshold is the row count from which sorting switches to a parallel merge
+// sort.
+const sortParallelThreshold = 1 << 16
+
+// sortKey orders the rows of a DataFrame by one column.
+type sortKey struct {
+	// compare is a three-way comparison of two non-null rows, in ascending order.
+	compare func(i, j int) int
+	// missing reports whether a row is null (or NaN, for float columns).
+	missing    func(i int) bool
+	descending bool
+}
+
+// newSortKey returns the sortKey of col. Float NaN values sort as nulls.
+func newSortKey(col Series, descending bool) sortKey {
+	missing := col.IsNull
+	if floats, ok := col.(*FloatCol); ok {
+		missing = func(i int) bool { return floats.IsNull(i) || math.IsNaN(floats.data[i]) }
+	}
+	return sortKey{compare: columnComparator(col), missing: missing, descending: descending}
+}
+
+// rowOrder returns a three-way comparison of two rows by keys, in order. Null values
+// sort before every other value when nullsFirst is set and after them otherwise, in
+// either direction. Rows that tie on every key compare by position, so any sort using
+// the comparison is stable.
+func rowOrder(keys []sortKey, nullsFirst bool) func(a, b int) int {
+	nullOrder := 1
+	if nullsFirst {
+		nullOrder = -1
+	}
+	return func(a, b int) int {
+		for _, key := range keys {
+			missingA, missingB := key.missing(a), key.missing(b)
+			switch {
+			case missingA && missingB:
+				continue
+			case missingA:
+				return nullOrder
+			case missingB:
+				return -nullOrder
+			}
+			if c := key.compare(a, b); c != 0 {
+				if key.descending {
+					return -c
+				}
+				return c
+			}
+		}
+		return cmp.Compare(a, b)
+	}
+}
+
+// sortKeys resolves the sort columns and directions of ArgSort and SortValues.
+func (df *DataFrame) sortKeys(by []string, ascending []bool) ([]sortKey, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+	if len(by) == 0 {
+		return nil, errors.New("at least one sort column is required")
+	}
+	if len(ascending) > 1 && len(ascending) != len(by) {
+		return nil, fmt.Errorf("got %d sort directions for %d columns", len(ascending), len(by))
+	}
+	positions, err := df.columnPositions(by)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]sortKey, len(by))
+	for k, pos := range positions {
+		up := true
+		switch len(ascending) {
+		case 1:
+			up = ascending[0]
+		case len(by):
+			up = ascending[k]
+		}
+		keys[k] = newSortKey(df.Data[pos], !up)
+	}
+	return keys, nil
+}
+
+// ArgSort returns the permutation of row positions that sorts the DataFrame by the given
+// columns, as used by SortValues. The permutation can be passed to Iloc with Positions,
+// or to Series.Take to reorder a column the same way.
+//
+// Parameters:
+//   - by: the columns to sort by, most significant first
+//   - ascending: the direction of each column in by, or a single direction for all of
+//     them; empty sorts every column ascending
+//   - nullsFirst: place nulls (and NaN floats) before the other values rather than after
+//
+// Returns:
+//   - The row positions of the DataFrame in sorted order
+//   - An error if the DataFrame is nil, by is empty or names a missing column, or
+//     ascending has the wrong length
+//
+// Example:
+//
+//	// df has a column price holding [3.5, null, 1.25]
+//	order, err := df.ArgSort([]string{"price"}, nil, false)
+//	// order: [2 0 1]
+func (df *DataFrame) ArgSort(by []string, ascending []bool, nullsFirst bool) ([]int, error) {
+	keys, err := df.sortKeys(by, ascending)
+	if err != nil {
+		return nil, err
+	}
+	order := make([]int, df.rowCount())
+	for i := range order {
+		order[i] = i
+	}
+	sortRows(order, rowOrder(keys, nullsFirst))
+	return order, nil
+}
+
+// SortValues returns a new DataFrame with the rows sorted by the given columns.
+//
+// The sort is stable: rows that tie on every column keep their relative order. Each
+// column is compared by its type (numbers numerically, strings lexically, times
+// chronologically, false before true), and nulls, including NaN floats, go first or last
+// as chosen whatever the direction. Large DataFrames are sorted with a parallel merge
+// sort.
+//
+// Parameters:
+//   - by: the columns to sort by, most significant first
+//   - ascending: the direction of each column in by, or a single direction for all of
+//     them; empty sorts every column ascending
+//   - nullsFirst: place nulls before the other values rather than after
+//
+// Returns:
+//   - A new DataFrame with the rows, and their index labels, in sorted order
+//   - An error if the DataFrame is nil, by is empty or names a missing column, or
+//     ascending has the wrong length
+//
+// Example:
+//
+//	// Newest orders first, and by customer within a day
+//	sorted, err := orders.SortValues([]string{"date", "customer"}, []bool{false, true}, false)
+func (df *DataFrame) SortValues(by []string, ascending []bool, nullsFirst bool) (*DataFrame, error) {
+	order, err := df.ArgSort(by, ascending, nullsFirst)
+	if err != nil {
+		return nil, err
+	}
+	return df.takeRows(order), nil
+}
+
+// SortIndex returns a new DataFrame with the rows sorted by their index labels. The sort
+// is stable and null labels go first or last as chosen.
+//
+// Parameters:
+//   - ascending: sort the labels in ascending order rather than descending
+//   - nullsFirst: place null labels before the other labels rather than after
+//
+// Returns:
+//   - A new DataFrame with the rows, and their index labels, in sorted order
+//   - An error if the DataFrame is nil
+//
+// Example:
+//
+//	// df is indexed by ticker
+//	byTicker, err := df.SortIndex(true, false)
+func (df *DataFrame) SortIndex(ascending bool, nullsFirst bool) (*DataFrame, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+	keys := []sortKey{newSortKey(df.RowIndex().Labels(), !ascending)}
+	order := make([]int, df.rowCount())
+	for i := range order {
+		order[i] = i
+	}
+	sortRows(order, rowOrder(keys, nullsFirst))
+	return df.takeRows(order), nil
+}
+
+// NLargest returns the n rows with the largest values in the given columns, in
+// descending order. Later columns break ties in earlier ones, and rows that tie on every
+// column keep their order. Rows with a null in any of the columns are left out.
+//
+// Only the n selected rows are kept while scanning the DataFrame, so this is cheaper than
+// sorting every row when n is small.
+//
+// Parameters:
+//   - n: the number of rows to return; fewer are returned if the DataFrame is shorter
+//   - columns: the columns to order by, at least one
+//
+// Returns:
+//   - A new DataFrame holding the selected rows, with their index labels
+//   - An error if the DataFrame is nil, n is negative, or columns is empty or names a
+//     missing column
+//
+// Example:
+//
+//	// The three biggest orders
+//	top, err := orders.NLargest(3, "total")
+func (df *DataFrame) NLargest(n int, columns ...string) (*DataFrame, error) {
+	return df.selectRows(n, columns, false)
+}
+
+// NSmallest returns the n rows with the smallest values in the given columns, in
+// ascending order. It is the counterpart of NLargest and follows the same rules.
+//
+// Example:
+//
+//	// The three cheapest products, by price and then weight
+//	cheapest, err := products.NSmallest(3, "price", "weight")
+func (df *DataFrame) NSmallest(n int, columns ...string) (*DataFrame, error) {
+	return df.selectRows(n, columns, true)
+}
+
+// selectRows implements NLargest and NSmallest. It keeps the best n rows seen so far in a
+// heap whose root is the worst of them, so each row costs at most O(log n).
+func (df *DataFrame) selectRows(n int, columns []string, ascending bool) (*DataFrame, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("n must not be negative, got %d", n)
+	}
+	keys, err := df.sortKeys(columns, []bool{ascending})
+	if err != nil {
+		return nil, err
+	}
+
+	order := rowOrder(keys, false)
+	selected := &rowHeap{compare: order}
+rows:
+	for i := 0; i < df.rowCount() && n > 0; i++ {
+		for _, key := range keys {
+			if key.missing(i) {
+				continue rows
+			}
+		}
+		switch {
+		case selected.Len() < n:
+			heap.Push(selected, i)
+		case order(i, selected.rows[0]) < 0:
+			selected.rows[0] = i
+			heap.Fix(selected, 0)
+		}
+	}
+	slices.SortFunc(selected.rows, order)
+	return df.takeRows(selected.rows), nil
+}
+
+// rowHeap is a heap of row positions whose root is the row that sorts last by compare.
+type rowHeap struct {
+	rows    []int
+	compare func(a, b int) int
+}
+
+func (h *rowHeap) Len() int           { return len(h.rows) }
+func (h *rowHeap) Less(i, j int) bool { return h.compare(h.rows[i], h.rows[j]) > 0 }
+func (h *rowHeap) Swap(i, j int)      { h.rows[i], h.rows[j] = h.rows[j], h.rows[i] }
+func (h *rowHeap) Push(x any)         { h.rows = append(h.rows, x.(int)) }
+func (h *rowHeap) Pop() any {
+	last := h.rows[len(h.rows)-1]
+	h.rows = h.rows[:len(h.rows)-1]
+	return last
+}
+
+// sortRows sorts row positions by compare, which must be a total order such as the one
+// returned by rowOrder. Large inputs are sorted with parallelMergeSort.
+func sortRows(rows []int, compare func(a, b int) int) {
+	if len(rows) < sortParallelThreshold || runtime.GOMAXPROCS(0) < 2 {
+		slices.SortFunc(rows, compare)
+		return
+	}
+	parallelMergeSort(rows, compare)
+}
+
+// parallelMergeSort sorts one chunk of rows per CPU concurrently, then merges adjacent
+// sorted runs pairwise, each merge in its own goroutine, until a single run is left.
+func parallelMergeSort(rows []int, compare func(a, b int) int) {
+	runs := splitRows(len(rows))
+	parallelChunks(runs, func(_, start, end int) {
+		slices.SortFunc(rows[start:end], compare)
+	})
+
+	src, dst := rows, make([]int, len(rows))
+	for len(runs) > 1 {
+		merged := make([][2]int, 0, (len(runs)+1)/2)
+		var wg sync.WaitGroup
+		for k := 0; k < len(runs); k += 2 {
+			if k+1 == len(runs) {
+				copy(dst[runs[k][0]:runs[k][1]], src[runs[k][0]:runs[k][1]])
+				merged = append(merged, runs[k])
+				continue
+			}
+			a, b := runs[k], runs[k+1]
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				mergeRuns(dst[a[0]:b[1]], src[a[0]:a[1]], src[b[0]:b[1]], compare)
+			}()
+			merged = append(merged, [2]int{a[0], b[1]})
+		}
+		wg.Wait()
+		runs = merged
+		src, dst = dst, src
+	}
+	if &src[0] != &rows[0] {
+		copy(rows, src)
+	}
+}
+
+// mergeRuns merges the sorted runs a and b into out, taking from a first on ties.
+func mergeRuns(out, a, b []int, compare func(a, b int) int) {
+	i, j := 0, 0
+	for k := range out {
+		if j == len(b) || (i < len(a) && compare(a[i], b[j]) <= 0) {
+			out[k] = a[i]
+			i++
+		} else {
+			out[k] = b[j]
+			j++
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package dataframe
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// StatIndexName is the name of the index of the DataFrames returned by the reductions
+// and Describe, whose labels name the statistic of each row.
+const StatIndexName = "stat"
+
+// Sum returns the sum of every int and float column. Other columns are left out. See
+// AggSum.
+func (df *DataFrame) Sum() (*DataFrame, error) { return df.Aggregate(AggSum) }
+
+// Mean returns the mean of every int and float column. Other columns are left out. See
+// AggMean.
+func (df *DataFrame) Mean() (*DataFrame, error) { return df.Aggregate(AggMean) }
+
+// Median returns the median of every int and float column. Other columns are left out.
+// See AggMedian.
+func (df *DataFrame) Median() (*DataFrame, error) { return df.Aggregate(AggMedian) }
+
+// Std returns the sample standard deviation of every int and float column. Other columns
+// are left out. See AggStd.
+func (df *DataFrame) Std() (*DataFrame, error) { return df.Aggregate(AggStd) }
+
+// Var returns the sample variance of every int and float column. Other columns are left
+// out. See AggVar.
+func (df *DataFrame) Var() (*DataFrame, error) { return df.Aggregate(AggVar) }
+
+// Min returns the smallest value of every column. See AggMin.
+func (df *DataFrame) Min() (*DataFrame, error) { return df.Aggregate(AggMin) }
+
+// Max returns the largest value of every column. See AggMax.
+func (df *DataFrame) Max() (*DataFrame, error) { return df.Aggregate(AggMax) }
+
+// Skew returns the sample skewness of every int and float column. Other columns are left
+// out. See AggSkew.
+func (df *DataFrame) Skew() (*DataFrame, error) { return df.Aggregate(AggSkew) }
+
+// Kurt returns the sample excess kurtosis of every int and float column. Other columns
+// are left out. See AggKurt.
+func (df *DataFrame) Kurt() (*DataFrame, error) { return df.Aggregate(AggKurt) }
+
+// Quantile returns the q-th quantile of every int and float column, interpolating
+// linearly. Other columns are left out. It fails unless q is between 0 and 1. See
+// AggQuantile.
+func (df *DataFrame) Quantile(q float64) (*DataFrame, error) { return df.Aggregate(AggQuantile(q)) }
+
+// Aggregate applies fn to every column of the DataFrame, reducing each to a single value.
+// Aggregations that need numbers (sum, mean, std, var, median, skew, kurt, quantiles)
+// skip columns of other dtypes. Null values are skipped by every built-in aggregation.
+//
+// Columns are aggregated concurrently.
+//
+// Returns:
+//   - A new DataFrame with one row, holding the aggregated columns under their names. Its
+//     index, named StatIndexName ("stat"), labels the row with the name of fn.
+//   - An error if the DataFrame is nil or fn fails.
+//
+// Example:
+//
+//	// df has columns city, age, score
+//	means, err := df.Mean()
+//	// stat | age  | score
+//	// mean | 41.5 | 0.82
+func (df *DataFrame) Aggregate(fn AggFunc) (*DataFrame, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+	all := make([]int, df.rowCount())
+	for i := range all {
+		all[i] = i
+	}
+	groups := [][]int{all}
+
+	var positions []int
+	for pos := range df.Columns {
+		if !fn.numeric || isNumeric(df.Data[pos]) {
+			positions = append(positions, pos)
+		}
+	}
+	out := &DataFrame{
+		Columns: make([]string, len(positions)),
+		Data:    make([]Series, len(positions)),
+		Index:   NewLabelIndex(StatIndexName, NewStringCol([]string{fn.Name})),
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(positions))
+	for c, pos := range positions {
+		out.Columns[c] = df.Columns[pos]
+		wg.Add(1)
+		go func(c int, col Series) {
+			defer wg.Done()
+			result, err := fn.apply(col, groups)
+			if err != nil {
+				errs[c] = fmt.Errorf("column '%s': %w", out.Columns[c], err)
+				return
+			}
+			out.Data[c] = result
+		}(c, df.Data[pos])
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// describeStats are the rows of Describe for numeric columns, in order.
+var describeStats = []AggFunc{AggCount, AggMean, AggStd, AggMin, AggQuantile(0.25), AggQuantile(0.5), AggQuantile(0.75), AggMax}
+
+// Describe returns summary statistics of the DataFrame, one column per described column
+// and one row per statistic.
+//
+// When the DataFrame has int or float columns, only those are described, with the rows
+// count, mean, std, min, 25%, 50%, 75% and max as floats. Otherwise every column is
+// described with the rows count, unique (the number of distinct values), top (the most
+// frequent value, the first one seen on a tie) and freq (its count). Nulls are skipped
+// throughout.
+//
+// Returns:
+//   - A new DataFrame whose index, named StatIndexName ("stat"), holds the statistic
+//     names, so that String shows them as the first column.
+//   - An error if the DataFrame is nil or has no columns, or if it has no numeric columns
+//     and a column holds values that cannot be hashed.
+//
+// Example:
+//
+//	summary, err := df.Describe()
+//	fmt.Println(summary)
+//	// stat  | age   | score
+//	// count | 4     | 3
+//	// mean  | 41.5  | 0.82
+//	// ...
+func (df *DataFrame) Describe() (*DataFrame, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+	if len(df.Columns) == 0 {
+		return nil, errors.New("DataFrame has no columns to describe")
+	}
+
+	var numeric []int
+	for pos, col := range df.Data {
+		if isNumeric(col) {
+			numeric = append(numeric, pos)
+		}
+	}
+	if len(numeric) == 0 {
+		return df.describeValues()
+	}
+
+	all := make([]int, df.rowCount())
+	for i := range all {
+		all[i] = i
+	}
+	labels := make([]string, len(describeStats))
+	for s, fn := range describeStats {
+		labels[s] = fn.Name
+	}
+	out := &DataFrame{
+		Columns: make([]string, len(numeric)),
+		Data:    make([]Series, len(numeric)),
+		Index:   NewLabelIndex(StatIndexName, NewStringCol(labels)),
+	}
+	var wg sync.WaitGroup
+	for c, pos := range numeric {
+		out.Columns[c] = df.Columns[pos]
+		wg.Add(1)
+		go func(c int, col Series) {
+			defer wg.Done()
+			parts := make([]Series, len(describeStats))
+			for s, fn := range describeStats {
+				parts[s] = fn.kernel(col, [][]int{all})
+			}
+			out.Data[c] = concatSeries(parts)
+		}(c, df.Data[pos])
+	}
+	wg.Wait()
+	return out, nil
+}
+
+// describeValues describes every column by count, unique, top and freq for Describe. It
+// fails if a column holds values that cannot be hashed.
+func (df *DataFrame) describeValues() (*DataFrame, error) {
+	out := &DataFrame{
+		Columns: append([]string(nil), df.Columns...),
+		Data:    make([]Series, len(df.Columns)),
+		Index:   NewLabelIndex(StatIndexName, NewStringCol([]string{"count", "unique", "top", "freq"})),
+	}
+	for c, col := range df.Data {
+		if err := checkHashable(col); err != nil {
+			return nil, fmt.Errorf("column '%s': %w", df.Columns[c], err)
+		}
+		counts, first := valueCounts(col)
+		var top any
+		var freq int64
+		for code, count := range counts {
+			if count > freq {
+				top, freq = col.At(first[code]), count
+			}
+		}
+		out.Data[c] = NewSeries([]any{int64(col.Len() - col.NullCount()), int64(len(counts)), top, freq})
+	}
+	return out, nil
+}
+
+// ValueCounts counts the occurrences of every distinct non-null value of a column. NaN is
+// left out like a null.
+//
+// Parameters:
+//   - col: the column to count
+//   - normalize: return the share of each value among the counted values instead of
+//     its count
+//
+// Returns:
+//   - A new DataFrame indexed by the distinct values, in an index named after col, with
+//     a single int column "count", or a float column "proportion" when normalize is set.
+//     Rows are sorted by decreasing count; values with the same count keep the order in
+//     which they first appear.
+//   - An error if the DataFrame is nil, col is not present or it holds values that cannot
+//     be hashed.
+//
+// Example:
+//
+//	// df has a column city holding [Paris, Rome, Paris, null, Berlin, Paris]
+//	counts, err := df.ValueCounts("city", false)
+//	// city   | count
+//	// Paris  | 3
+//	// Rome   | 1
+//	// Berlin | 1
+func (df *DataFrame) ValueCounts(col string, normalize bool) (*DataFrame, error) {
+	if df == nil {
+		return nil, errors.New("DataFrame is nil")
+	}
+	positions, err := df.columnPositions([]string{col})
+	if err != nil {
+		return nil, err
+	}
+	series := df.Data[positions[0]]
+	if err := checkHashable(series); err != nil {
+		return nil, fmt.Errorf("column '%s': %w", col, err)
+	}
+	counts, first := valueCounts(series)
+
+	order := make([]int, len(counts))
+	for code := range order {
+		order[code] = code
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return cmp.Compare(counts[b], counts[a]) })
+	rows := make([]int, len(order))
+	for i, code := range order {
+		rows[i] = first[code]
+	}
+	out := &DataFrame{Index: NewLabelIndex(col, series.Take(rows))}
+
+	if !normalize {
+		values := make([]int64, len(order))
+		for i, code := range order {
+			values[i] = counts[code]
+		}
+		out.Columns = []string{"count"}
+		out.Data = []Series{NewIntCol(values)}
+		return out, nil
+	}
+	var total float64
+	for _, count := range counts {
+		total += float64(count)
+	}
+	shares := make([]float64, len(order))
+	for i, code := range order {
+		shares[i] = float64(counts[code]) / total
+	}
+	out.Columns = []string{"proportion"}
+	out.Data = []Series{NewFloatCol(shares)}
+	return out, nil
+}
+
+// valueCounts factorizes col and returns the number of rows holding each code, and the
+// first row holding it. Codes follow the order in which values first appear.
+func valueCounts(col Series) (counts []int64, first []int) {
+	codes, cardinality := factorize(col)
+	counts = make([]int64, cardinality)
+	first = make([]int, cardinality)
+	for row, code := range codes {
+		if code < 0 {
+			continue
+		}
+		if counts[code] == 0 {
+			first[code] = row
+		}
+		counts[code]++
+	}
+	return counts, first
+}
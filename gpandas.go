@@ -1,46 +1,49 @@
 package gpandas
 
 import (
-	"encoding/csv"
 	"errors"
 	"fmt"
 	"gpandas/dataframe"
 	"io"
 	"os"
-	"runtime"
-	"sync"
+	"slices"
 )
 
 type GoPandas struct{}
 
-// FloatColumn represents a slice of float64 values.
-type FloatCol []float64
+// FloatCol is the typed float64 column used by DataFrame.
+type FloatCol = dataframe.FloatCol
 
-// StringColumn represents a slice of string values.
-type StringCol []string
+// StringCol is the typed string column used by DataFrame.
+type StringCol = dataframe.StringCol
 
-// IntColumn represents a slice of int64 values.
-type IntCol []int64
+// IntCol is the typed int64 column used by DataFrame.
+type IntCol = dataframe.IntCol
 
-// BoolColumn represents a slice of bool values.
-type BoolCol []bool
+// BoolCol is the typed bool column used by DataFrame.
+type BoolCol = dataframe.BoolCol
+
+// DateTimeCol is the typed time.Time column used by DataFrame.
+type DateTimeCol = dataframe.DateTimeCol
 
 // Column represents a slice of any type.
-type Column []any
+type Column = dataframe.Column
 
-// TypeColumn represents a slice of a comparable type T.
-type TypeColumn[T comparable] []T
+// DefaultNAValues lists the CSV cell values, besides the empty string, that Read_csv
+// treats as null.
+var DefaultNAValues = []string{"NA", "N/A", "n/a", "NaN", "nan", "null", "NULL", "None", "<NA>", "#N/A"}
 
-func FloatColumn(col []any) ([]float64, error) {
-	floatCol := make(FloatCol, len(col))
-	for i, v := range col {
-		if val, ok := v.(float64); ok {
-			floatCol[i] = val
-		} else {
-			return nil, fmt.Errorf("invalid type for column %d: expected float64, got %T", i, v)
+// isNAValue reports whether a raw CSV cell represents a missing value.
+func isNAValue(val string) bool {
+	if val == "" {
+		return true
+	}
+	for _, na := range DefaultNAValues {
+		if val == na {
+			return true
 		}
 	}
-	return floatCol, nil
+	return false
 }
 
 // DataFrame creates a new DataFrame from the provided columns, data, and column types.
@@ -55,8 +58,11 @@ func FloatColumn(col []any) ([]float64, error) {
 // - Validates all columns have the same length
 // - Ensures type definitions exist for all columns
 //
-// The data is then converted to the internal DataFrame format, performing type assertions
-// based on the specified column types (FloatCol, IntCol, StringCol, BoolCol).
+// The data is then converted to the internal DataFrame format, storing each column as a
+// typed Series chosen from the specified column types (FloatCol, IntCol, StringCol, BoolCol,
+// DateTimeCol).
+// Integer values of any width are accepted for IntCol and stored as int64. Columns with any
+// other type definition are stored as a dataframe.ObjectCol.
 //
 // Parameters:
 //
@@ -103,43 +109,32 @@ func (GoPandas) DataFrame(columns []string, data []Column, columns_types map[str
 	// Create DataFrame
 	df := &dataframe.DataFrame{
 		Columns: columns,
-		Data:    make([][]any, len(columns)),
+		Data:    make([]dataframe.Series, len(columns)),
 	}
 
 	// Convert data to internal format
 	for i, col := range data {
-		df.Data[i] = make([]any, rowCount)
-		for j, val := range col {
-			// Type assertion based on columns_types using defined types
-			switch columns_types[columns[i]].(type) {
-			case FloatCol:
-				if v, ok := val.(float64); ok {
-					df.Data[i][j] = FloatCol{v}
-				} else {
-					return nil, fmt.Errorf("type mismatch for column %s: expected FloatColumn, got %T", columns[i], val)
-				}
-			case IntCol:
-				if v, ok := val.(int64); ok {
-					df.Data[i][j] = IntCol{v}
-				} else {
-					return nil, fmt.Errorf("type mismatch for column %s: expected IntColumn, got %T", columns[i], val)
-				}
-			case StringCol:
-				if v, ok := val.(string); ok {
-					df.Data[i][j] = StringCol{v}
-				} else {
-					return nil, fmt.Errorf("type mismatch for column %s: expected StringColumn, got %T", columns[i], val)
-				}
-			case BoolCol:
-				if v, ok := val.(bool); ok {
-					df.Data[i][j] = BoolCol{v}
-				} else {
-					return nil, fmt.Errorf("type mismatch for column %s: expected BoolColumn, got %T", columns[i], val)
-				}
-			default:
-				df.Data[i][j] = val // Fallback for any other type
-			}
+		// Type assertion based on columns_types using defined types
+		var dtype dataframe.DType
+		switch columns_types[columns[i]].(type) {
+		case FloatCol, *FloatCol:
+			dtype = dataframe.FloatType
+		case IntCol, *IntCol:
+			dtype = dataframe.IntType
+		case StringCol, *StringCol:
+			dtype = dataframe.StringType
+		case BoolCol, *BoolCol:
+			dtype = dataframe.BoolType
+		case DateTimeCol, *DateTimeCol:
+			dtype = dataframe.DateTimeType
+		default:
+			dtype = dataframe.ObjectType // Fallback for any other type
+		}
+		series, err := dataframe.NewSeriesAs(dtype, col)
+		if err != nil {
+			return nil, fmt.Errorf("type mismatch for column %s: %w", columns[i], err)
 		}
+		df.Data[i] = series
 	}
 
 	return df, nil
@@ -153,118 +148,129 @@ func (GoPandas) DataFrame(columns []string, data []Column, columns_types map[str
 //
 // It initializes data columns based on the number of headers and populates them with the corresponding values from the records.
 //
-// If the number of columns in any row is inconsistent with the header, an error is returned.
+// If the number of columns in any row is inconsistent with the header, an error reporting the
+// line number and raw content of the row is returned; ReadCSVOptions.OnBadLines can skip such
+// rows instead. An error is also returned if the file has no data rows or if the CSV reader
+// fails part way through the file.
+//
+// Each column's dtype (int64, float64, bool, datetime or string) is inferred from a sample
+// of its values, falling back to a wider dtype when a later value does not fit (see
+// ReadCSVOptions). Empty cells and the tokens listed in DefaultNAValues are stored as nulls.
+//
+// Rows are parsed concurrently in chunks and reassembled by chunk index, so the
+// DataFrame always keeps the row order of the file.
 //
-// The function also creates a map of column types, defaulting to StringCol for all columns.
+// The separator, header row, selected columns, skipped preamble lines, row limit, comment
+// character and quoting rules are configured through ReadCSVOptions.
+//
+// Files compressed with gzip, bzip2 or zstd are decompressed transparently. The format is
+// detected from the extension (.gz, .bz2, .zst) or, failing that, from the magic bytes at
+// the start of the file; ReadCSVOptions.Compression overrides detection.
 //
 // Finally, it calls the DataFrame constructor to create and return a DataFrame containing the data from the CSV file.
 //
 // Parameters:
 //
 //	filepath: A string representing the path to the CSV file to be read.
+//	opts: Optional ReadCSVOptions; only the first one is used.
 //
 // Returns:
 //
 //	A pointer to a DataFrame containing the data from the CSV file, or an error if the operation fails.
-func (GoPandas) Read_csv(filepath string) (*dataframe.DataFrame, error) {
+//
+// Example:
+//
+//	gp := gpandas.GoPandas{}
+//	// Infer every column
+//	df, err := gp.Read_csv("people.csv")
+//	// Keep zip codes as strings instead of inferring int64
+//	df, err := gp.Read_csv("people.csv", gpandas.ReadCSVOptions{
+//	    DType: map[string]dataframe.DType{"zip": dataframe.StringType},
+//	})
+//	// Read the first 1000 rows of two columns from a pipe-delimited export with a
+//	// two-line preamble
+//	df, err := gp.Read_csv("export.psv", gpandas.ReadCSVOptions{
+//	    Sep:      '|',
+//	    SkipRows: 2,
+//	    UseCols:  []string{"id", "amount"},
+//	    NRows:    1000,
+//	})
+//	// Read a gzip-compressed export
+//	df, err := gp.Read_csv("export.csv.gz")
+func (GoPandas) Read_csv(filepath string, opts ...ReadCSVOptions) (*dataframe.DataFrame, error) {
+	var options ReadCSVOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
 	file, err := os.Open(filepath)
 	if err != nil {
 		return nil, fmt.Errorf("error opening file: %w", err)
 	}
 	defer file.Close()
 
-	reader := csv.NewReader(file)
-
-	// Read header
-	headers, err := reader.Read()
-	if err != nil {
-		return nil, fmt.Errorf("error reading headers: %w", err)
-	}
-
-	columnCount := len(headers)
-	if columnCount == 0 {
-		return nil, errors.New("no headers found in CSV")
-	}
+	return readCSV(file, filepath, options)
+}
 
-	// Use a worker pool for dynamic workload distribution
-	type RowData struct {
-		Index int
-		Row   []string
+// ReadCSVFrom reads CSV data from r and converts it into a DataFrame.
+//
+// It behaves exactly like Read_csv, using the same parallel parsing pipeline and options,
+// but reads from any io.Reader such as an HTTP response body or an object storage reader.
+// Since there is no file name to inspect, compressed input is detected from its magic bytes
+// unless ReadCSVOptions.Compression names the format explicitly.
+//
+// Parameters:
+//
+//	r: The source of the CSV data. It is read to the end but not closed.
+//	opts: Optional ReadCSVOptions; only the first one is used.
+//
+// Returns:
+//
+//	A pointer to a DataFrame containing the CSV data, or an error if the operation fails.
+//
+// Example:
+//
+//	resp, err := http.Get("https://example.com/export.csv.gz")
+//	if err != nil {
+//	    return err
+//	}
+//	defer resp.Body.Close()
+//	df, err := gp.ReadCSVFrom(resp.Body)
+func (GoPandas) ReadCSVFrom(r io.Reader, opts ...ReadCSVOptions) (*dataframe.DataFrame, error) {
+	var options ReadCSVOptions
+	if len(opts) > 0 {
+		options = opts[0]
 	}
-	rowChan := make(chan RowData, 100)                 // Buffered channel to hold rows
-	resultChan := make(chan [][]any, runtime.NumCPU()) // Channel to hold columnar data
-	var wg sync.WaitGroup
-
-	// Start workers for processing rows
-	for i := 0; i < runtime.NumCPU(); i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-
-			// Local column buffers
-			localData := make([][]any, columnCount)
-			for i := range localData {
-				localData[i] = make([]any, 0, 100) // Preallocate some space
-			}
+	return readCSV(r, "", options)
+}
 
-			for row := range rowChan {
-				if len(row.Row) != columnCount {
-					// Handle inconsistent row lengths
-					continue
-				}
-				for j, val := range row.Row {
-					localData[j] = append(localData[j], val)
-				}
-			}
-			resultChan <- localData
-		}()
+// readCSV decompresses r if needed and parses it into a DataFrame. name is the file name
+// of the input, used to detect compression by extension, or "" when there is none.
+func readCSV(r io.Reader, name string, options ReadCSVOptions) (*dataframe.DataFrame, error) {
+	source, err := openCSVSource(r, name, options)
+	if err != nil {
+		return nil, err
 	}
+	defer source.Close()
 
-	// Feed rows to workers
-	go func() {
-		index := 0
-		for {
-			record, err := reader.Read()
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				close(rowChan)
-				return
-			}
-			rowChan <- RowData{Index: index, Row: record}
-			index++
-		}
-		close(rowChan)
-	}()
-
-	// Wait for workers to finish
-	go func() {
-		wg.Wait()
-		close(resultChan)
-	}()
-
-	// Combine results into columnar format
-	combinedData := make([][]any, columnCount)
-	for i := range combinedData {
-		combinedData[i] = make([]any, 0)
+	// Parse the rows in parallel, keeping file order
+	combinedData, err := parseCSVRecords(source, options)
+	if err != nil {
+		return nil, err
 	}
-
-	for localData := range resultChan {
-		for i := range localData {
-			combinedData[i] = append(combinedData[i], localData[i]...)
-		}
+	if len(combinedData) > 0 && len(combinedData[0]) == 0 {
+		return nil, errors.New("no data rows found in CSV")
 	}
 
-	// Infer column types (default to string for now)
-	columnTypes := make(map[string]any, columnCount)
-	for _, header := range headers {
-		columnTypes[header] = StringCol{} // Placeholder for type inference
+	// Convert each column to its explicit or inferred dtype
+	data, err := buildCSVColumns(source.names, combinedData, options)
+	if err != nil {
+		return nil, err
 	}
 
 	// Construct DataFrame
 	return &dataframe.DataFrame{
-		Columns: headers,
-		Data:    combinedData,
+		Columns: slices.Clone(source.names),
+		Data:    data,
 	}, nil
 }
@@ -0,0 +1,1321 @@
+package gpandas
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"gpandas/dataframe"
+	"gpandas/utils/collection"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// DefaultInferSampleSize is the number of non-null values per column that Read_csv
+// inspects when inferring a column's dtype.
+const DefaultInferSampleSize = 1000
+
+// NoHeader is the ReadCSVOptions.Header value for files without a header row.
+const NoHeader = -1
+
+// ReadCSVOptions configures how Read_csv parses a CSV file. The zero value reads a
+// comma-separated file whose first line is the header.
+//
+// Fields:
+//   - Sep: field separator (defaults to ',')
+//   - Header: index of the header record, counted after SkipRows (defaults to 0). Records
+//     before the header are discarded. Use NoHeader when the file has no header row;
+//     columns are then named by Names or "0", "1", ... by position.
+//   - Names: column names to use instead of the header record
+//   - UseCols: names of the columns to keep, in file order (defaults to all columns).
+//     Other columns are dropped while parsing.
+//   - SkipRows: number of raw lines to skip at the start of the file, e.g. a preamble
+//   - NRows: maximum number of data rows to read (0 reads every row)
+//   - Comment: lines starting with this character are ignored (0 disables comments)
+//   - Quote: character enclosing quoted fields (defaults to '"')
+//   - Escape: character escaping the next character inside or outside quotes
+//     (0 means quotes are escaped by doubling them, as in RFC 4180)
+//   - LazyQuotes: allow quotes to appear in unquoted fields and non-doubled quotes in
+//     quoted fields
+//   - OnBadLines: what to do with rows whose field count differs from the header
+//     (defaults to BadLinesError)
+//   - BadLineCallback: called for every bad line under BadLinesWarn; when nil, a warning
+//     is written to standard error
+//   - DType: explicit dtype per column name. Listed columns skip inference and every
+//     value must parse as the given dtype. Unlisted columns are inferred.
+//   - InferSampleSize: number of non-null values per column inspected during inference
+//     (defaults to DefaultInferSampleSize; a negative value inspects every value)
+//   - Compression: compression of the input (defaults to CompressionInfer)
+type ReadCSVOptions struct {
+	Sep             rune
+	Header          int
+	Names           []string
+	UseCols         []string
+	SkipRows        int
+	NRows           int
+	Comment         rune
+	Quote           rune
+	Escape          rune
+	LazyQuotes      bool
+	OnBadLines      BadLinePolicy
+	BadLineCallback func(BadLine)
+	DType           map[string]dataframe.DType
+	InferSampleSize int
+	Compression     Compression
+}
+
+// Compression identifies the compression format of CSV input.
+type Compression string
+
+const (
+	// CompressionInfer detects the format from the file extension (.gz, .bz2, .zst) and,
+	// failing that, from the magic bytes at the start of the input.
+	CompressionInfer Compression = ""
+	// CompressionNone reads the input as plain text.
+	CompressionNone Compression = "none"
+	// CompressionGzip reads gzip-compressed input.
+	CompressionGzip Compression = "gzip"
+	// CompressionBzip2 reads bzip2-compressed input.
+	CompressionBzip2 Compression = "bzip2"
+	// CompressionZstd reads zstd-compressed input.
+	CompressionZstd Compression = "zstd"
+)
+
+// compressionMagic maps each supported compression format to the bytes its streams start with.
+var compressionMagic = []struct {
+	compression Compression
+	magic       []byte
+}{
+	{CompressionGzip, []byte{0x1f, 0x8b}},
+	{CompressionBzip2, []byte("BZh")},
+	{CompressionZstd, []byte{0x28, 0xb5, 0x2f, 0xfd}},
+}
+
+// compressionFromExtension returns the compression implied by the extension of name, or
+// CompressionInfer when the extension does not name a compression format.
+func compressionFromExtension(name string) Compression {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".gz", ".gzip":
+		return CompressionGzip
+	case ".bz2":
+		return CompressionBzip2
+	case ".zst", ".zstd":
+		return CompressionZstd
+	default:
+		return CompressionInfer
+	}
+}
+
+// decompressCSV wraps r in a decompressor for the given compression.
+//
+// CompressionInfer first looks at the extension of name (which may be empty for inputs
+// without a file name) and then at the magic bytes at the start of r. Input that matches
+// no known format is read as plain text.
+//
+// Parameters:
+//   - r: the raw input
+//   - name: file name of the input, used for extension-based detection
+//   - compression: the compression of r
+//
+// Returns:
+//   - a reader yielding the decompressed CSV text; the caller must close it
+//   - an error if compression is unknown or the compressed stream header is invalid
+func decompressCSV(r io.Reader, name string, compression Compression) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	if compression == CompressionInfer {
+		compression = compressionFromExtension(name)
+	}
+	if compression == CompressionInfer {
+		compression = CompressionNone
+		for _, m := range compressionMagic {
+			if head, _ := br.Peek(len(m.magic)); bytes.Equal(head, m.magic) {
+				compression = m.compression
+				break
+			}
+		}
+	}
+
+	switch compression {
+	case CompressionNone:
+		return io.NopCloser(br), nil
+	case CompressionGzip:
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("error opening gzip stream: %w", err)
+		}
+		return gz, nil
+	case CompressionBzip2:
+		return io.NopCloser(bzip2.NewReader(br)), nil
+	case CompressionZstd:
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("error opening zstd stream: %w", err)
+		}
+		return zr.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression: %s", compression)
+	}
+}
+
+// BadLinePolicy selects how Read_csv handles rows whose field count differs from the header.
+type BadLinePolicy string
+
+const (
+	// BadLinesError stops reading and returns the BadLine as an error.
+	BadLinesError BadLinePolicy = "error"
+	// BadLinesSkip silently drops bad lines.
+	BadLinesSkip BadLinePolicy = "skip"
+	// BadLinesWarn drops bad lines and reports each one to ReadCSVOptions.BadLineCallback.
+	BadLinesWarn BadLinePolicy = "warn"
+)
+
+// BadLine describes a CSV row whose field count differs from the header.
+// It implements error so it can be returned under BadLinesError.
+//
+// Fields:
+//   - Line: line number in the input on which the row starts (1-based)
+//   - Raw: the unparsed text of the row
+//   - Fields: the parsed fields of the row
+//   - Expected: the number of fields in the header
+type BadLine struct {
+	Line     int
+	Raw      string
+	Fields   []string
+	Expected int
+}
+
+// Error describes the bad line.
+func (b BadLine) Error() string {
+	return fmt.Sprintf("bad line %d: expected %d fields, got %d: %q", b.Line, b.Expected, len(b.Fields), b.Raw)
+}
+
+// recordReader reads one CSV record at a time.
+type recordReader interface {
+	// Read returns the next record, or io.EOF when the input is exhausted.
+	Read() ([]string, error)
+	// Last returns the line number on which the most recent record started and its
+	// raw, unparsed text.
+	Last() (line int, raw string)
+	// Rest returns the input following the most recent record, which the reader no
+	// longer uses, and the line number it starts on.
+	Rest() (rest io.Reader, line int)
+}
+
+// newRecordReader skips the first opts.SkipRows raw lines of r and returns a reader for
+// the remaining records.
+func newRecordReader(r io.Reader, opts ReadCSVOptions) (recordReader, error) {
+	br := bufio.NewReader(r)
+	skipped := 0
+	for i := 0; i < opts.SkipRows; i++ {
+		if _, err := br.ReadString('\n'); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("error skipping rows: %w", err)
+		}
+		skipped++
+	}
+	return recordReaderAt(br, opts, skipped), nil
+}
+
+// recordReaderAt returns a reader for the records of br, whose first line is line
+// lineOffset+1 of the input.
+//
+// The standard encoding/csv reader is used unless a custom quote or escape character is
+// configured, in which case a quoteReader handles the parsing. Line numbers reported by
+// either reader count the lines before br, so they match the line numbers of the input.
+func recordReaderAt(br *bufio.Reader, opts ReadCSVOptions, lineOffset int) recordReader {
+	if usesStdReader(opts) {
+		return newStdRecordReader(&rawTracker{r: br}, opts, lineOffset)
+	}
+
+	sep := opts.Sep
+	if sep == 0 {
+		sep = ','
+	}
+	quote := opts.Quote
+	if quote == 0 {
+		quote = '"'
+	}
+	return &quoteReader{r: br, sep: sep, quote: quote, escape: opts.Escape, comment: opts.Comment, line: lineOffset}
+}
+
+// newStdRecordReader returns an encoding/csv based recordReader that reads through raw.
+func newStdRecordReader(raw *rawTracker, opts ReadCSVOptions, lineOffset int) *stdRecordReader {
+	reader := csv.NewReader(raw)
+	reader.Comma = opts.Sep
+	if reader.Comma == 0 {
+		reader.Comma = ','
+	}
+	reader.Comment = opts.Comment
+	reader.LazyQuotes = opts.LazyQuotes
+	reader.FieldsPerRecord = -1 // field counts are validated against the header by Read_csv
+	return &stdRecordReader{csv: reader, raw: raw, comment: opts.Comment, lineOffset: lineOffset}
+}
+
+// usesStdReader reports whether the records described by opts are parsed by encoding/csv.
+func usesStdReader(opts ReadCSVOptions) bool {
+	return (opts.Quote == 0 || opts.Quote == '"') && opts.Escape == 0
+}
+
+// rawTracker records the bytes read through it so the raw text of a parsed record can be
+// recovered from its input offsets. Only bytes from offset base onward are retained. A
+// tracker created with held set already holds all of its input in buf and copies nothing.
+type rawTracker struct {
+	r    io.Reader
+	buf  []byte
+	base int64
+	held bool
+}
+
+// Read reads from the underlying reader and retains the bytes read.
+func (t *rawTracker) Read(p []byte) (int, error) {
+	n, err := t.r.Read(p)
+	if !t.held {
+		t.buf = append(t.buf, p[:n]...)
+	}
+	return n, err
+}
+
+// release drops the retained bytes before offset off.
+func (t *rawTracker) release(off int64) {
+	t.buf = t.buf[off-t.base:]
+	t.base = off
+}
+
+// text returns the bytes between offsets start and end.
+func (t *rawTracker) text(start, end int64) string {
+	return string(t.buf[start-t.base : end-t.base])
+}
+
+// stdRecordReader adapts encoding/csv to recordReader.
+type stdRecordReader struct {
+	csv        *csv.Reader
+	raw        *rawTracker
+	comment    rune
+	lineOffset int   // lines skipped before the csv.Reader started
+	line       int   // line on which the most recent record starts
+	start, end int64 // input offsets of the most recent record and the lines skipped before it
+}
+
+// Read returns the next record, or io.EOF when the input is exhausted.
+func (s *stdRecordReader) Read() ([]string, error) {
+	s.raw.release(s.end)
+	start := s.csv.InputOffset()
+	record, err := s.csv.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			parseErr.StartLine += s.lineOffset
+			parseErr.Line += s.lineOffset
+		}
+		return nil, err
+	}
+
+	firstLine, _ := s.csv.FieldPos(0)
+	s.line = firstLine + s.lineOffset
+	s.start, s.end = start, s.csv.InputOffset()
+	return record, nil
+}
+
+// recordText returns the raw text of the most recent record including its line break.
+// The bytes since the previous record may start with blank or comment lines, which
+// encoding/csv skips and which therefore never begin a record.
+func (s *stdRecordReader) recordText() string {
+	text := s.raw.text(s.start, s.end)
+	for {
+		idx := strings.IndexByte(text, '\n')
+		if idx < 0 {
+			return text
+		}
+		line := text[:idx]
+		blank := line == "" || line == "\r"
+		comment := s.comment != 0 && strings.HasPrefix(line, string(s.comment))
+		if !blank && !comment {
+			return text
+		}
+		text = text[idx+1:]
+	}
+}
+
+// Last returns the line number and raw text of the most recent record.
+func (s *stdRecordReader) Last() (int, string) {
+	return s.line, strings.TrimRight(s.recordText(), "\r\n")
+}
+
+// Rest returns the bytes the csv.Reader has buffered beyond the most recent record,
+// followed by the unread input.
+func (s *stdRecordReader) Rest() (io.Reader, int) {
+	rest := io.MultiReader(bytes.NewReader(s.raw.buf[s.end-s.raw.base:]), s.raw.r)
+	if s.end == 0 {
+		return rest, s.lineOffset + 1
+	}
+	return rest, s.line + strings.Count(s.recordText(), "\n")
+}
+
+// quoteReader parses CSV records with a configurable quote and escape character.
+//
+// It follows the same rules as encoding/csv: fields may be enclosed in quotes, quoted
+// fields may contain separators and newlines, a doubled quote inside a quoted field is a
+// literal quote, empty lines are skipped and a trailing \r before a newline is dropped.
+// In addition, an escape character makes the following character literal.
+type quoteReader struct {
+	r         *bufio.Reader
+	sep       rune
+	quote     rune
+	escape    rune
+	comment   rune
+	line      int    // last line consumed
+	raw       []byte // raw text of the record being parsed
+	startLine int
+}
+
+// Read returns the next record, or io.EOF when the input is exhausted.
+func (q *quoteReader) Read() ([]string, error) {
+	for {
+		record, err := q.readRecord()
+		if err != nil || record != nil {
+			return record, err
+		}
+	}
+}
+
+// Last returns the line number and raw text of the most recent record.
+func (q *quoteReader) Last() (int, string) {
+	return q.startLine, strings.TrimRight(string(q.raw), "\r\n")
+}
+
+// Rest returns the unread input.
+func (q *quoteReader) Rest() (io.Reader, int) {
+	return q.r, q.line + 1
+}
+
+// next reads a rune and appends it to the raw text of the current record.
+func (q *quoteReader) next() (rune, error) {
+	r, _, err := q.r.ReadRune()
+	if err == nil {
+		q.raw = utf8.AppendRune(q.raw, r)
+		if r == '\n' {
+			q.line++
+		}
+	}
+	return r, err
+}
+
+// unread pushes the most recently read rune back and removes it from the raw text.
+func (q *quoteReader) unread() {
+	if err := q.r.UnreadRune(); err == nil {
+		_, size := utf8.DecodeLastRune(q.raw)
+		if q.raw[len(q.raw)-size] == '\n' {
+			q.line--
+		}
+		q.raw = q.raw[:len(q.raw)-size]
+	}
+}
+
+// readRecord parses a single line, returning a nil record for empty and comment lines.
+func (q *quoteReader) readRecord() ([]string, error) {
+	var (
+		record  []string
+		field   strings.Builder
+		inQuote bool
+		started bool // whether the current line has any content
+	)
+	q.raw = q.raw[:0]
+	q.startLine = q.line + 1
+
+	for {
+		r, err := q.next()
+		if err == io.EOF {
+			if inQuote {
+				return nil, fmt.Errorf("record on line %d: unterminated quoted field", q.startLine)
+			}
+			if !started {
+				return nil, io.EOF
+			}
+			return append(record, field.String()), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if !started && !inQuote {
+			if r == '\n' {
+				return nil, nil
+			}
+			if q.comment != 0 && r == q.comment {
+				if _, err := q.r.ReadString('\n'); err != nil && err != io.EOF {
+					return nil, err
+				}
+				q.line++
+				return nil, nil
+			}
+		}
+		started = true
+
+		switch {
+		case q.escape != 0 && r == q.escape:
+			next, err := q.next()
+			if err != nil {
+				return nil, fmt.Errorf("record on line %d: escape character at end of input", q.startLine)
+			}
+			field.WriteRune(next)
+		case inQuote && r == q.quote:
+			next, err := q.next()
+			if err == nil && next == q.quote {
+				field.WriteRune(q.quote)
+				continue
+			}
+			if err == nil {
+				q.unread()
+			}
+			inQuote = false
+		case inQuote:
+			field.WriteRune(r)
+		case r == q.quote && field.Len() == 0:
+			inQuote = true
+		case r == q.sep:
+			record = append(record, field.String())
+			field.Reset()
+		case r == '\n':
+			return append(record, strings.TrimSuffix(field.String(), "\r")), nil
+		default:
+			field.WriteRune(r)
+		}
+	}
+}
+
+// csvSource is CSV input positioned after its header, with the columns to keep resolved.
+type csvSource struct {
+	input       io.ReadCloser
+	splitter    *csvSplitter // the input following the header
+	pending     []csvBlock   // records parsed but not yet returned, which precede the splitter
+	columnCount int          // number of fields in the header
+	positions   []int        // field positions of the selected columns
+	names       []string     // names of the selected columns
+}
+
+// openCSVSource decompresses r, reads its header and resolves the selected columns.
+//
+// Parameters:
+//   - r: the raw input
+//   - name: file name of the input, used to detect compression by extension ("" if none)
+//   - opts: the read options
+//
+// Returns:
+//   - the source, which the caller must close
+//   - an error if the options are invalid or the header cannot be read
+func openCSVSource(r io.Reader, name string, opts ReadCSVOptions) (*csvSource, error) {
+	if opts.NRows < 0 {
+		return nil, fmt.Errorf("nrows must be non-negative, got %d", opts.NRows)
+	}
+
+	input, err := decompressCSV(r, name, opts.Compression)
+	if err != nil {
+		return nil, err
+	}
+	source := &csvSource{input: input}
+
+	reader, err := newRecordReader(input, opts)
+	if err != nil {
+		input.Close()
+		return nil, err
+	}
+
+	// Read header
+	headers, pending, err := readCSVHeader(reader, opts)
+	if err != nil {
+		input.Close()
+		return nil, err
+	}
+
+	source.columnCount = len(headers)
+	if source.columnCount == 0 {
+		input.Close()
+		return nil, errors.New("no headers found in CSV")
+	}
+
+	// Resolve the columns to keep
+	source.positions, source.names, err = selectCSVColumns(headers, opts)
+	if err != nil {
+		input.Close()
+		return nil, err
+	}
+
+	// Validate explicit dtypes refer to existing columns
+	for col := range opts.DType {
+		if !slices.Contains(source.names, col) {
+			input.Close()
+			return nil, fmt.Errorf("dtype specified for unknown column: %s", col)
+		}
+	}
+
+	// The records after the header are parsed in ranges; a first record read while
+	// locating the header comes before them
+	if pending != nil {
+		block := csvBlock{Columns: make([][]string, len(source.positions)), Rows: 1}
+		for j, pos := range source.positions {
+			block.Columns[j] = []string{pending[pos]}
+		}
+		source.pending = []csvBlock{block}
+	}
+	source.splitter = newCSVSplitter(reader, opts)
+	return source, nil
+}
+
+// Close releases the decompressor of the source.
+func (s *csvSource) Close() error {
+	return s.input.Close()
+}
+
+// readCSVHeader reads the header record selected by opts and returns the column names.
+//
+// With NoHeader, the first data record is read to count the columns and is returned as
+// pending so that it is not lost; otherwise pending is nil.
+func readCSVHeader(reader recordReader, opts ReadCSVOptions) (headers []string, pending []string, err error) {
+	if opts.Header < NoHeader {
+		return nil, nil, fmt.Errorf("invalid header index: %d", opts.Header)
+	}
+
+	if opts.Header == NoHeader {
+		pending, err = reader.Read()
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading first row: %w", err)
+		}
+		headers = make([]string, len(pending))
+		for i := range headers {
+			headers[i] = strconv.Itoa(i)
+		}
+	} else {
+		for i := 0; i <= opts.Header; i++ {
+			headers, err = reader.Read()
+			if err != nil {
+				return nil, nil, fmt.Errorf("error reading headers: %w", err)
+			}
+		}
+	}
+
+	if opts.Names != nil {
+		if len(opts.Names) != len(headers) {
+			return nil, nil, fmt.Errorf("expected %d names, got %d", len(headers), len(opts.Names))
+		}
+		headers = append([]string(nil), opts.Names...)
+	}
+	return headers, pending, nil
+}
+
+// selectCSVColumns resolves opts.UseCols against the header and returns the positions of
+// the kept columns, in file order, along with their names.
+func selectCSVColumns(headers []string, opts ReadCSVOptions) ([]int, []string, error) {
+	if len(opts.UseCols) == 0 {
+		positions := make([]int, len(headers))
+		for i := range positions {
+			positions[i] = i
+		}
+		return positions, headers, nil
+	}
+
+	wanted, err := collection.ToSet(opts.UseCols)
+	if err != nil {
+		return nil, nil, err
+	}
+	available, err := collection.ToSet(headers)
+	if err != nil {
+		return nil, nil, err
+	}
+	missing, err := wanted.Difference(available)
+	if err != nil {
+		return nil, nil, err
+	}
+	for col := range missing {
+		return nil, nil, fmt.Errorf("usecols column not found in CSV: %s", col)
+	}
+
+	var positions []int
+	var names []string
+	for i, header := range headers {
+		if wanted.Has(header) {
+			positions = append(positions, i)
+			names = append(names, header)
+		}
+	}
+	return positions, names, nil
+}
+
+// boolValues maps the CSV spellings of booleans recognized during inference.
+var boolValues = map[string]bool{
+	"true": true, "True": true, "TRUE": true,
+	"false": false, "False": false, "FALSE": false,
+}
+
+// dateTimeLayouts lists the layouts tried, in order, when parsing datetime cells.
+var dateTimeLayouts = []string{
+	time.DateOnly,
+	time.DateTime,
+	"2006-01-02T15:04:05",
+	time.RFC3339Nano,
+}
+
+// parseDateTime parses a datetime cell using the first matching layout in dateTimeLayouts.
+func parseDateTime(val string) (time.Time, error) {
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.Parse(layout, val); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as datetime", val)
+}
+
+// inferDType returns the narrowest dtype that parses every sampled non-null value of a
+// column. Candidates are tried in the order int64, float64, bool, datetime and string.
+//
+// Only the first sampleSize non-null values are inspected (every value when sampleSize
+// is negative). A column with no non-null values is inferred as float64, matching the
+// all-NaN columns of pandas.
+func inferDType(values []string, sampleSize int) dataframe.DType {
+	isInt, isFloat, isBool, isDateTime := true, true, true, true
+	seen := 0
+	for _, val := range values {
+		if isNAValue(val) {
+			continue
+		}
+		if sampleSize >= 0 && seen >= sampleSize {
+			break
+		}
+		seen++
+
+		if isInt {
+			if _, err := strconv.ParseInt(val, 10, 64); err != nil {
+				isInt = false
+			}
+		}
+		if isFloat {
+			if _, err := strconv.ParseFloat(val, 64); err != nil {
+				isFloat = false
+			}
+		}
+		if isBool {
+			if _, ok := boolValues[val]; !ok {
+				isBool = false
+			}
+		}
+		if isDateTime {
+			if _, err := parseDateTime(val); err != nil {
+				isDateTime = false
+			}
+		}
+		if !isInt && !isFloat && !isBool && !isDateTime {
+			return dataframe.StringType
+		}
+	}
+
+	switch {
+	case seen == 0:
+		return dataframe.FloatType
+	case isInt:
+		return dataframe.IntType
+	case isFloat:
+		return dataframe.FloatType
+	case isBool:
+		return dataframe.BoolType
+	case isDateTime:
+		return dataframe.DateTimeType
+	default:
+		return dataframe.StringType
+	}
+}
+
+// parseColumn converts the raw cells of a column into a Series of the given dtype,
+// storing NA cells as nulls.
+//
+// Returns an error naming the first row whose value does not parse as dtype.
+func parseColumn(values []string, dtype dataframe.DType) (dataframe.Series, error) {
+	switch dtype {
+	case dataframe.IntType:
+		return parseTyped(values, dtype, func(val string) (int64, error) {
+			return strconv.ParseInt(val, 10, 64)
+		})
+	case dataframe.FloatType:
+		return parseTyped(values, dtype, func(val string) (float64, error) {
+			return strconv.ParseFloat(val, 64)
+		})
+	case dataframe.BoolType:
+		return parseTyped(values, dtype, func(val string) (bool, error) {
+			b, ok := boolValues[val]
+			if !ok {
+				return false, fmt.Errorf("invalid bool %q", val)
+			}
+			return b, nil
+		})
+	case dataframe.DateTimeType:
+		return parseTyped(values, dtype, parseDateTime)
+	case dataframe.StringType, dataframe.ObjectType:
+		return parseTyped(values, dataframe.StringType, func(val string) (string, error) {
+			return val, nil
+		})
+	default:
+		return nil, fmt.Errorf("unsupported dtype: %s", dtype)
+	}
+}
+
+// parseTyped applies parse to every non-NA cell and collects the results into a TypeColumn.
+func parseTyped[T comparable](values []string, dtype dataframe.DType, parse func(string) (T, error)) (dataframe.Series, error) {
+	out := dataframe.NewTypeColumn(make([]T, len(values)))
+	typed := out.Values()
+	for i, val := range values {
+		if isNAValue(val) {
+			out.SetNull(i)
+			continue
+		}
+		v, err := parse(val)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: cannot parse %q as %s", i, val, dtype)
+		}
+		typed[i] = v
+	}
+	return out, nil
+}
+
+// inferColumn infers the dtype of a column from a sample and parses the full column.
+//
+// When a value outside the sample does not parse as the inferred dtype, the column falls
+// back to a wider dtype: int64 values that overflow or are mixed with decimals fall back
+// to float64, and anything else falls back to string.
+func inferColumn(values []string, sampleSize int) dataframe.Series {
+	dtype := inferDType(values, sampleSize)
+	if series, err := parseColumn(values, dtype); err == nil {
+		return series
+	}
+	if dtype == dataframe.IntType {
+		if series, err := parseColumn(values, dataframe.FloatType); err == nil {
+			return series
+		}
+	}
+	series, _ := parseColumn(values, dataframe.StringType)
+	return series
+}
+
+// csvRangeSize is the approximate number of bytes of input handed to a parsing worker at
+// a time. Ranges are extended to the end of the record they stop in.
+const csvRangeSize = 256 << 10
+
+// csvRange is a run of complete records of the input. Index is the position of the range
+// in the file and Line the input line it starts on.
+type csvRange struct {
+	Index int
+	Line  int
+	Data  []byte
+}
+
+// csvSplitter cuts CSV input into csvRanges of about csvRangeSize bytes that each end on
+// a record boundary, so that every range can be parsed on its own.
+//
+// It follows just enough of the CSV syntax to tell a newline that ends a record from one
+// inside a quoted field or a comment: quotes open a field only at its start, a doubled
+// quote is a literal quote, an escape character makes the next character literal and,
+// with LazyQuotes, a quote that is not followed by a separator or a newline stays inside
+// the field, as in encoding/csv. Malformed records are left for the parser to report.
+type csvSplitter struct {
+	r     io.Reader
+	buf   []byte // input read but not yet handed out
+	line  int    // input line on which buf starts
+	index int    // index of the next range
+	eof   bool   // whether r is exhausted
+	err   error  // read error, returned once the complete records before it are handed out
+
+	sep, quote, escape, comment rune
+	lazy                        bool
+	special                     [utf8.RuneSelf]bool // ASCII bytes scan must look at
+	ascii                       bool                // whether every rune scan looks at is in special
+
+	// scan state, kept across reads while a range is being delimited
+	pos                                  int // next byte of buf to scan
+	cut                                  int // end of the last complete record in buf
+	inQuote, quoteSeen, quoteCR, escaped bool
+	inComment, recordStart, fieldStart   bool
+}
+
+// newCSVSplitter returns a splitter over the input of reader that follows its most recent
+// record.
+func newCSVSplitter(reader recordReader, opts ReadCSVOptions) *csvSplitter {
+	rest, line := reader.Rest()
+	s := &csvSplitter{
+		r:           rest,
+		line:        line,
+		sep:         opts.Sep,
+		quote:       opts.Quote,
+		escape:      opts.Escape,
+		comment:     opts.Comment,
+		lazy:        opts.LazyQuotes && usesStdReader(opts),
+		recordStart: true,
+		fieldStart:  true,
+	}
+	if s.sep == 0 {
+		s.sep = ','
+	}
+	if s.quote == 0 {
+		s.quote = '"'
+	}
+	s.ascii = true
+	for _, r := range []rune{'\n', s.sep, s.quote, s.escape, s.comment} {
+		if r >= utf8.RuneSelf {
+			s.ascii = false
+		} else if r != 0 {
+			s.special[r] = true
+		}
+	}
+	return s
+}
+
+// next returns the next range of complete records, or io.EOF once the input is exhausted.
+func (s *csvSplitter) next() (csvRange, error) {
+	complete := s.scan()
+	for !complete && !s.eof {
+		s.fill()
+		complete = s.scan()
+	}
+	cut := s.cut
+	if !complete && s.err == nil {
+		// The last record may lack a trailing newline
+		cut = len(s.buf)
+	}
+	if cut == 0 {
+		if s.err != nil {
+			return csvRange{}, s.err
+		}
+		return csvRange{}, io.EOF
+	}
+
+	r := csvRange{Index: s.index, Line: s.line, Data: s.buf[:cut]}
+	s.index++
+	s.line += bytes.Count(r.Data, []byte{'\n'})
+	// The range now belongs to a worker, so the rest moves to a buffer of its own
+	s.buf = append(make([]byte, 0, len(s.buf)-cut+csvRangeSize), s.buf[cut:]...)
+	s.pos -= cut
+	s.cut = 0
+	return r, nil
+}
+
+// fill appends up to csvRangeSize bytes of input to buf.
+func (s *csvSplitter) fill() {
+	s.buf = slices.Grow(s.buf, csvRangeSize)
+	n, err := io.ReadFull(s.r, s.buf[len(s.buf):len(s.buf)+csvRangeSize])
+	s.buf = s.buf[:len(s.buf)+n]
+	switch err {
+	case nil:
+	case io.EOF, io.ErrUnexpectedEOF:
+		s.eof = true
+	default:
+		s.eof = true
+		s.err = fmt.Errorf("error reading CSV: %w", err)
+	}
+}
+
+// scan advances through buf, recording the end of every record in cut, and reports
+// whether a record ending at or past csvRangeSize has been found.
+func (s *csvSplitter) scan() bool {
+	for s.pos < len(s.buf) {
+		if s.inComment {
+			idx := bytes.IndexByte(s.buf[s.pos:], '\n')
+			if idx < 0 {
+				s.pos = len(s.buf)
+				return false
+			}
+			s.pos += idx
+		} else if s.ascii && !s.escaped && !s.quoteSeen && !s.quoteCR {
+			// Skip the bytes that cannot change the state in one pass
+			start := s.pos
+			for s.pos < len(s.buf) && (s.buf[s.pos] >= utf8.RuneSelf || !s.special[s.buf[s.pos]]) {
+				s.pos++
+			}
+			if s.pos > start && !s.inQuote {
+				s.recordStart = false
+				s.fieldStart = false
+			}
+			if s.pos == len(s.buf) {
+				return false
+			}
+		}
+
+		r, size := rune(s.buf[s.pos]), 1
+		if r >= utf8.RuneSelf {
+			if !s.eof && !utf8.FullRune(s.buf[s.pos:]) {
+				return false
+			}
+			r, size = utf8.DecodeRune(s.buf[s.pos:])
+		}
+		s.pos += size
+
+		switch {
+		case s.escaped:
+			s.escaped = false
+			continue
+		case s.quoteCR:
+			// A lazy quote followed by \r closes the field only before \n
+			s.quoteCR = false
+			if r != '\n' {
+				continue
+			}
+			s.inQuote = false
+		case s.quoteSeen:
+			s.quoteSeen = false
+			if r == s.quote {
+				continue
+			}
+			if s.lazy && r == '\r' {
+				s.quoteCR = true
+				continue
+			}
+			if s.lazy && r != s.sep && r != '\n' {
+				continue
+			}
+			s.inQuote = false
+		case s.inComment:
+			if r == '\n' {
+				s.inComment = false
+				s.endRecord()
+			}
+			continue
+		}
+
+		switch {
+		case s.escape != 0 && r == s.escape:
+			s.escaped = true
+		case s.inQuote:
+			if r == s.quote {
+				s.quoteSeen = true
+			}
+			continue
+		case s.recordStart && s.comment != 0 && r == s.comment:
+			s.inComment = true
+			continue
+		case r == '\n':
+			s.endRecord()
+			if s.cut >= csvRangeSize {
+				return true
+			}
+			continue
+		case r == s.quote && s.fieldStart:
+			s.inQuote = true
+		case r == s.sep:
+			s.recordStart = false
+			s.fieldStart = true
+			continue
+		}
+		s.recordStart = false
+		s.fieldStart = false
+	}
+	return false
+}
+
+// endRecord marks the end of a record at the current scan position.
+func (s *csvSplitter) endRecord() {
+	s.cut = s.pos
+	s.recordStart = true
+	s.fieldStart = true
+}
+
+// csvBlock holds the parsed records of a csvRange: the cells of the selected columns of
+// its good records in column-major layout, the bad lines found between them and the
+// error, if any, that stopped the parsing after them.
+type csvBlock struct {
+	Index    int
+	Columns  [][]string
+	Rows     int
+	BadLines []blockBadLine
+	Err      error
+}
+
+// blockBadLine is a bad line of a csvBlock, found after Row good records of the block.
+type blockBadLine struct {
+	Row int
+	Bad BadLine
+}
+
+// parseCSVRange parses the records of rng into a csvBlock.
+func parseCSVRange(rng csvRange, columnCount int, positions []int, opts ReadCSVOptions) csvBlock {
+	var reader recordReader
+	if usesStdReader(opts) {
+		// The range is already in memory, so record text is sliced from it rather than copied
+		raw := &rawTracker{r: bytes.NewReader(rng.Data), buf: rng.Data, held: true}
+		reader = newStdRecordReader(raw, opts, rng.Line-1)
+	} else {
+		reader = recordReaderAt(bufio.NewReader(bytes.NewReader(rng.Data)), opts, rng.Line-1)
+	}
+	estimate := bytes.Count(rng.Data, []byte{'\n'}) + 1
+	block := csvBlock{Index: rng.Index, Columns: make([][]string, len(positions))}
+	for j := range block.Columns {
+		block.Columns[j] = make([]string, 0, estimate)
+	}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			block.Err = fmt.Errorf("error reading CSV: %w", err)
+			break
+		}
+		if len(record) != columnCount {
+			if opts.OnBadLines != BadLinesSkip {
+				line, raw := reader.Last()
+				bad := BadLine{Line: line, Raw: raw, Fields: record, Expected: columnCount}
+				block.BadLines = append(block.BadLines, blockBadLine{Row: block.Rows, Bad: bad})
+			}
+			continue
+		}
+		for j, pos := range positions {
+			block.Columns[j] = append(block.Columns[j], record[pos])
+		}
+		block.Rows++
+	}
+	return block
+}
+
+// split divides the block after its first n rows. The bad lines found after the nth row
+// and the error go to the second part.
+func (b csvBlock) split(n int) (csvBlock, csvBlock) {
+	head := csvBlock{Index: b.Index, Columns: make([][]string, len(b.Columns)), Rows: n}
+	tail := csvBlock{Index: b.Index, Columns: make([][]string, len(b.Columns)), Rows: b.Rows - n, Err: b.Err}
+	for j, cells := range b.Columns {
+		head.Columns[j], tail.Columns[j] = cells[:n], cells[n:]
+	}
+	for _, bad := range b.BadLines {
+		if bad.Row < n {
+			head.BadLines = append(head.BadLines, bad)
+		} else {
+			tail.BadLines = append(tail.BadLines, blockBadLine{Row: bad.Row - n, Bad: bad.Bad})
+		}
+	}
+	return head, tail
+}
+
+// parseCSVRecords reads the next records of source and returns the cells of the selected
+// columns in column-major layout, preserving file order.
+//
+// The input is cut into ranges of about csvRangeSize bytes that end on record boundaries,
+// and a pool of runtime.GOMAXPROCS(0) workers tokenizes the ranges concurrently. Parsed
+// ranges are consumed in index order, so the result and the order in which bad lines are
+// reported are deterministic regardless of which worker finishes first.
+//
+// Once opts.NRows rows have been collected no further ranges are handed out; the records
+// of ranges already parsed beyond the limit are kept in source.pending for the next call,
+// which lets CSVChunkReader read a file one chunk at a time.
+//
+// Records whose field count differs from the header are handled according to
+// opts.OnBadLines.
+//
+// Parameters:
+//   - source: the input, positioned after the header or the records returned before
+//   - opts: row limit and bad line handling
+//
+// Returns:
+//   - the selected columns in column-major layout
+//   - an error if the reader fails mid-stream or a bad line is found under BadLinesError
+func parseCSVRecords(source *csvSource, opts ReadCSVOptions) ([][]string, error) {
+	switch opts.OnBadLines {
+	case "":
+		opts.OnBadLines = BadLinesError
+	case BadLinesError, BadLinesSkip, BadLinesWarn:
+	default:
+		return nil, fmt.Errorf("invalid bad line policy: %s", opts.OnBadLines)
+	}
+
+	var blocks []csvBlock
+	need := opts.NRows
+	full := false
+	// take consumes block, reporting its bad lines, until the row limit is reached. What
+	// is left once it is goes back to source.pending for the next call.
+	take := func(block csvBlock) (bool, error) {
+		if full {
+			source.pending = append(source.pending, block)
+			return true, nil
+		}
+		if opts.NRows > 0 && need <= block.Rows {
+			var rest csvBlock
+			block, rest = block.split(need)
+			source.pending = append(source.pending, rest)
+			full = true
+		}
+		for _, bad := range block.BadLines {
+			if opts.OnBadLines == BadLinesError {
+				return full, bad.Bad
+			}
+			if opts.BadLineCallback != nil {
+				opts.BadLineCallback(bad.Bad)
+			} else {
+				fmt.Fprintf(os.Stderr, "gpandas: skipping %v\n", bad.Bad)
+			}
+		}
+		if block.Err != nil {
+			return full, block.Err
+		}
+		blocks = append(blocks, block)
+		need -= block.Rows
+		return full, nil
+	}
+
+	pending := source.pending
+	source.pending = nil
+	for _, block := range pending {
+		if _, err := take(block); err != nil {
+			return nil, err
+		}
+	}
+
+	if !full {
+		if err := parseCSVRanges(source, opts, take); err != nil {
+			return nil, err
+		}
+	}
+
+	// Concatenate blocks in file order
+	combinedData := make([][]string, len(source.positions))
+	for j := range combinedData {
+		total := 0
+		for _, block := range blocks {
+			total += len(block.Columns[j])
+		}
+		combinedData[j] = make([]string, 0, total)
+		for _, block := range blocks {
+			combinedData[j] = append(combinedData[j], block.Columns[j]...)
+		}
+	}
+	return combinedData, nil
+}
+
+// parseCSVRanges hands the ranges of source.splitter to a pool of workers and passes the
+// parsed blocks to take in index order. It stops handing out ranges once take reports
+// that it has enough rows, but still passes it every block already parsed, so that no
+// record is lost, and stops at the first error.
+func parseCSVRanges(source *csvSource, opts ReadCSVOptions, take func(csvBlock) (enough bool, err error)) error {
+	workers := runtime.GOMAXPROCS(0)
+	rangeChan := make(chan csvRange, workers)
+	blockChan := make(chan csvBlock, workers)
+	stop := make(chan struct{})
+	nextIndex := source.splitter.index
+	var wg sync.WaitGroup
+
+	// Start workers tokenizing ranges
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for rng := range rangeChan {
+				blockChan <- parseCSVRange(rng, source.columnCount, source.positions, opts)
+			}
+		}()
+	}
+
+	// Feed ranges to the workers until the input ends or the consumer has enough rows
+	var feedErr error
+	go func() {
+		defer close(rangeChan)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			rng, err := source.splitter.next()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				feedErr = err
+				return
+			}
+			rangeChan <- rng
+		}
+	}()
+
+	// Wait for workers to finish
+	go func() {
+		wg.Wait()
+		close(blockChan)
+	}()
+
+	// Consume blocks in index order as they arrive
+	var takeErr error
+	parsed := make(map[int]csvBlock)
+	stopped := false
+	for block := range blockChan {
+		parsed[block.Index] = block
+		for {
+			next, ok := parsed[nextIndex]
+			if !ok {
+				break
+			}
+			delete(parsed, nextIndex)
+			nextIndex++
+			if takeErr != nil {
+				continue
+			}
+			enough, err := take(next)
+			if (enough || err != nil) && !stopped {
+				close(stop)
+				stopped = true
+			}
+			takeErr = err
+		}
+	}
+
+	// blockChan is closed only after the feeder closed rangeChan, so feedErr is settled
+	if takeErr != nil {
+		return takeErr
+	}
+	if !stopped {
+		return feedErr
+	}
+	return nil
+}
+
+// buildCSVColumns converts raw column-major CSV cells into typed Series, honouring the
+// explicit dtypes in opts and inferring the rest. Columns are converted concurrently.
+//
+// Returns an error if a column with an explicit dtype contains a value that does not parse.
+func buildCSVColumns(headers []string, columns [][]string, opts ReadCSVOptions) ([]dataframe.Series, error) {
+	sampleSize := opts.InferSampleSize
+	if sampleSize == 0 {
+		sampleSize = DefaultInferSampleSize
+	}
+
+	data := make([]dataframe.Series, len(columns))
+	errs := make([]error, len(columns))
+	var wg sync.WaitGroup
+	for i := range columns {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if dtype, ok := opts.DType[headers[i]]; ok {
+				series, err := parseColumn(columns[i], dtype)
+				if err != nil {
+					errs[i] = fmt.Errorf("column %s: %w", headers[i], err)
+					return
+				}
+				data[i] = series
+				return
+			}
+			data[i] = inferColumn(columns[i], sampleSize)
+		}(i)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,228 @@
+package gpandas
+
+import (
+	"errors"
+	"fmt"
+	"gpandas/dataframe"
+	"io"
+	"maps"
+	"os"
+	"slices"
+)
+
+// CSVChunkReader reads a CSV file as a sequence of DataFrames of at most ChunkSize rows,
+// so files larger than memory can be processed with bounded memory use.
+//
+// Every chunk is parsed with the same parallel pipeline as Read_csv and honours the same
+// ReadCSVOptions; NRows limits the total number of rows across all chunks.
+//
+// Column dtypes are inferred once, from the first chunk, and then fixed: every later chunk
+// is parsed with exactly those dtypes, so all chunks share one schema. A column whose values
+// are all null so far is not fixed yet; it is returned as an all-null float64 column and its
+// dtype is inferred from the first chunk that holds a value in it. A later value that does
+// not parse as its column's dtype (for example a decimal in a column whose first chunk held
+// only integers) makes Next return an error naming the column and the chunk; pass the
+// column in ReadCSVOptions.DType to fix its dtype up front.
+//
+// A CSVChunkReader is not safe for concurrent use. Call Close when done with it.
+type CSVChunkReader struct {
+	source    *csvSource
+	file      io.Closer // file opened by ReadCSVChunks, nil for ReadCSVChunksFrom
+	opts      ReadCSVOptions
+	chunkSize int
+	chunks    int // number of chunks returned so far
+	rowsRead  int
+	done      bool
+}
+
+// ReadCSVChunks opens the CSV file at filepath for chunked reading.
+//
+// The header is read immediately, so errors in the options or header are reported here
+// rather than by the first call to Next.
+//
+// Parameters:
+//
+//	filepath: A string representing the path to the CSV file to be read.
+//	chunkSize: The maximum number of rows in each chunk; must be positive.
+//	opts: Optional ReadCSVOptions; only the first one is used.
+//
+// Returns:
+//
+//	A CSVChunkReader positioned at the first data row, or an error if the file cannot be
+//	opened or its header cannot be read.
+//
+// Example:
+//
+//	chunks, err := gp.ReadCSVChunks("events.csv", 100000)
+//	if err != nil {
+//	    return err
+//	}
+//	defer chunks.Close()
+//	for {
+//	    df, err := chunks.Next()
+//	    if err == io.EOF {
+//	        break
+//	    }
+//	    if err != nil {
+//	        return err
+//	    }
+//	    process(df)
+//	}
+func (GoPandas) ReadCSVChunks(filepath string, chunkSize int, opts ...ReadCSVOptions) (*CSVChunkReader, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+	chunks, err := newCSVChunkReader(file, filepath, chunkSize, opts)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	chunks.file = file
+	return chunks, nil
+}
+
+// ReadCSVChunksFrom is ReadCSVChunks for CSV data read from r. Compressed input is
+// detected from its magic bytes as in ReadCSVFrom. r is not closed by the chunk reader.
+func (GoPandas) ReadCSVChunksFrom(r io.Reader, chunkSize int, opts ...ReadCSVOptions) (*CSVChunkReader, error) {
+	return newCSVChunkReader(r, "", chunkSize, opts)
+}
+
+// newCSVChunkReader opens a csvSource over r and wraps it in a CSVChunkReader.
+func newCSVChunkReader(r io.Reader, name string, chunkSize int, opts []ReadCSVOptions) (*CSVChunkReader, error) {
+	if chunkSize <= 0 {
+		return nil, fmt.Errorf("chunk size must be positive, got %d", chunkSize)
+	}
+	var options ReadCSVOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	source, err := openCSVSource(r, name, options)
+	if err != nil {
+		return nil, err
+	}
+	return &CSVChunkReader{
+		source:    source,
+		opts:      options,
+		chunkSize: chunkSize,
+	}, nil
+}
+
+// Columns returns the names of the columns in every chunk. The slice is a copy.
+func (c *CSVChunkReader) Columns() []string {
+	return slices.Clone(c.source.names)
+}
+
+// DTypes returns a copy of the dtype of every column once the first chunk has been read,
+// or nil before that. Columns that have held only nulls so far are left out.
+func (c *CSVChunkReader) DTypes() map[string]dataframe.DType {
+	if c.rowsRead == 0 {
+		return nil
+	}
+	return maps.Clone(c.opts.DType)
+}
+
+// Next reads the next chunk of at most chunkSize rows.
+//
+// Returns:
+//   - the next chunk as a DataFrame
+//   - io.EOF once every row has been read (or NRows rows when a limit is set), or any
+//     error from reading or parsing the chunk. After an error, Next keeps returning io.EOF.
+func (c *CSVChunkReader) Next() (*dataframe.DataFrame, error) {
+	if c.done {
+		return nil, io.EOF
+	}
+
+	// Limit this chunk so the total does not exceed NRows
+	limit := c.chunkSize
+	if c.opts.NRows > 0 && c.opts.NRows-c.rowsRead < limit {
+		limit = c.opts.NRows - c.rowsRead
+	}
+	if limit == 0 {
+		c.done = true
+		return nil, io.EOF
+	}
+	chunkOpts := c.opts
+	chunkOpts.NRows = limit
+
+	columns, err := parseCSVRecords(c.source, chunkOpts)
+	if err != nil {
+		c.done = true
+		return nil, err
+	}
+	rows := len(columns[0])
+	if rows == 0 {
+		c.done = true
+		if c.rowsRead == 0 {
+			return nil, errors.New("no data rows found in CSV")
+		}
+		return nil, io.EOF
+	}
+
+	data, err := buildCSVColumns(c.source.names, columns, c.opts)
+	if err != nil {
+		c.done = true
+		return nil, fmt.Errorf("chunk %d (starting at row %d): %w", c.chunks, c.rowsRead, err)
+	}
+
+	// Fix the dtype of every column that has held a value for every later chunk
+	if c.rowsRead == 0 {
+		dtypes := make(map[string]dataframe.DType, len(data))
+		for _, name := range c.source.names {
+			if dtype, ok := c.opts.DType[name]; ok {
+				dtypes[name] = dtype
+			}
+		}
+		c.opts.DType = dtypes
+	}
+	for i, series := range data {
+		if _, ok := c.opts.DType[c.source.names[i]]; !ok && series.NullCount() < series.Len() {
+			c.opts.DType[c.source.names[i]] = series.DType()
+		}
+	}
+	c.chunks++
+	c.rowsRead += rows
+
+	// Every chunk owns its column names, so renaming one chunk leaves the schema alone
+	return &dataframe.DataFrame{
+		Columns: slices.Clone(c.source.names),
+		Data:    data,
+	}, nil
+}
+
+// All returns an iterator over the remaining chunks that stops after the first error.
+// Its signature matches iter.Seq2[*dataframe.DataFrame, error], so with Go 1.23 or newer
+// it can be used directly in a range loop:
+//
+//	for df, err := range chunks.All() {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    process(df)
+//	}
+func (c *CSVChunkReader) All() func(yield func(*dataframe.DataFrame, error) bool) {
+	return func(yield func(*dataframe.DataFrame, error) bool) {
+		for {
+			df, err := c.Next()
+			if err == io.EOF {
+				return
+			}
+			if !yield(df, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Close releases the decompressor and, for ReadCSVChunks, the underlying file.
+func (c *CSVChunkReader) Close() error {
+	c.done = true
+	err := c.source.Close()
+	if c.file != nil {
+		if ferr := c.file.Close(); err == nil {
+			err = ferr
+		}
+	}
+	return err
+}
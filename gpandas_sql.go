@@ -65,7 +65,8 @@ func connect_to_db(db_config *DbConfig) (*sql.DB, error) {
 //
 // The DataFrame's structure will match the query results:
 //   - Columns will be named according to the SELECT statement
-//   - Data types will be preserved from the database types
+//   - Data types will be preserved from the database types, each column stored as the
+//     narrowest typed Series that holds its values ([]byte values are stored as strings)
 //
 // Examples:
 //
@@ -136,9 +137,15 @@ func (GoPandas) Read_sql(query string, db_config DbConfig) (*dataframe.DataFrame
 		return nil, fmt.Errorf("error iterating over rows: %w", err)
 	}
 
+	// Store each column as the narrowest Series holding its values
+	series := make([]dataframe.Series, columnCount)
+	for i := range data {
+		series[i] = dataframe.NewSeries(data[i])
+	}
+
 	return &dataframe.DataFrame{
 		Columns: columns,
-		Data:    data,
+		Data:    series,
 	}, nil
 }
 
@@ -203,14 +210,12 @@ func (GoPandas) From_gbq(query string, projectID string) (*dataframe.DataFrame,
 		columns = append(columns, col)
 	}
 
-	// first row in columns row
-	firstDataRow := make([]any, len(columns))
+	// Store the data column-major, seeding every column with the first row
+	data := make([][]any, len(columns))
 	for i, col := range columns {
-		firstDataRow[i] = firstRow[col]
+		data[i] = []any{firstRow[col]}
 	}
 
-	data := [][]any{firstDataRow}
-
 	// Process actual data here
 	for {
 		var row map[string]bigquery.Value
@@ -222,16 +227,20 @@ func (GoPandas) From_gbq(query string, projectID string) (*dataframe.DataFrame,
 			return nil, fmt.Errorf("iterator.Next: %v", err)
 		}
 
-		// Build a row in the same column order
-		interfaceRow := make([]any, len(columns))
+		// Append the row to each column in the same column order
 		for i, col := range columns {
-			interfaceRow[i] = row[col]
+			data[i] = append(data[i], row[col])
 		}
-		data = append(data, interfaceRow)
+	}
+
+	// Store each column as the narrowest Series holding its values
+	series := make([]dataframe.Series, len(columns))
+	for i := range data {
+		series[i] = dataframe.NewSeries(data[i])
 	}
 
 	return &dataframe.DataFrame{
 		Columns: columns,
-		Data:    data,
+		Data:    series,
 	}, nil
 }
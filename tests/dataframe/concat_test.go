@@ -0,0 +1,233 @@
+package dataframe_test
+
+import (
+	"gpandas/dataframe"
+	"strings"
+	"testing"
+)
+
+// newDayFrames returns two daily extracts with overlapping columns: the first has date
+// and an int sales column, the second date, a float sales column and returns.
+func newDayFrames() []*dataframe.DataFrame {
+	return []*dataframe.DataFrame{
+		{
+			Columns: []string{"date", "sales"},
+			Data: toSeries([][]any{
+				{"2024-01-01", "2024-01-01"},
+				{120, nil},
+			}),
+		},
+		{
+			Columns: []string{"date", "sales", "returns"},
+			Data: toSeries([][]any{
+				{"2024-01-02"},
+				{98.5},
+				{3},
+			}),
+		},
+	}
+}
+
+// TestConcat tests stacking DataFrames by rows and placing them side by side.
+//
+// The test suite covers:
+//   - Outer and inner joins of the column names, with nulls for missing columns
+//   - Int and float columns combining into a float column
+//   - Stacked index labels, IgnoreIndex and Keys tagging each row with its source
+//   - Aligning rows by index label, or by position with IgnoreIndex
+//   - Invalid inputs and options
+func TestConcat(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    dataframe.ConcatOptions
+		columns []string
+		values  [][]any
+		labels  []any
+	}{
+		{
+			name:    "outer rows",
+			columns: []string{"date", "sales", "returns"},
+			values: [][]any{
+				{"2024-01-01", "2024-01-01", "2024-01-02"},
+				{120.0, nil, 98.5},
+				{nil, nil, int64(3)},
+			},
+			labels: []any{int64(0), int64(1), int64(0)},
+		},
+		{
+			name:    "inner rows",
+			opts:    dataframe.ConcatOptions{Join: dataframe.ConcatInner, IgnoreIndex: true},
+			columns: []string{"date", "sales"},
+			values: [][]any{
+				{"2024-01-01", "2024-01-01", "2024-01-02"},
+				{120.0, nil, 98.5},
+			},
+			labels: []any{int64(0), int64(1), int64(2)},
+		},
+		{
+			name:    "keys",
+			opts:    dataframe.ConcatOptions{Keys: []string{"mon", "tue"}, KeyColumn: "day", IgnoreIndex: true},
+			columns: []string{"date", "sales", "returns", "day"},
+			values: [][]any{
+				{"2024-01-01", "2024-01-01", "2024-01-02"},
+				{120.0, nil, 98.5},
+				{nil, nil, int64(3)},
+				{"mon", "mon", "tue"},
+			},
+			labels: []any{int64(0), int64(1), int64(2)},
+		},
+		{
+			name:    "outer columns by position",
+			opts:    dataframe.ConcatOptions{Axis: dataframe.ConcatColumns, IgnoreIndex: true},
+			columns: []string{"a", "b", "c"},
+			values: [][]any{
+				{int64(1), int64(2), int64(3)},
+				{"x", "y", nil},
+				{true, nil, nil},
+			},
+			labels: []any{int64(0), int64(1), int64(2)},
+		},
+		{
+			name:    "inner columns by position",
+			opts:    dataframe.ConcatOptions{Axis: dataframe.ConcatColumns, Join: dataframe.ConcatInner, IgnoreIndex: true},
+			columns: []string{"a", "b", "c"},
+			values: [][]any{
+				{int64(1)},
+				{"x"},
+				{true},
+			},
+			labels: []any{int64(0)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frames := newDayFrames()
+			if tt.opts.Axis == dataframe.ConcatColumns {
+				frames = []*dataframe.DataFrame{
+					{Columns: []string{"a"}, Data: toSeries([][]any{{1, 2, 3}})},
+					{Columns: []string{"b"}, Data: toSeries([][]any{{"x", "y"}})},
+					{Columns: []string{"c"}, Data: toSeries([][]any{{true}})},
+				}
+			}
+			result, err := dataframe.ConcatWith(frames, tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			checkFrame(t, result, tt.columns, tt.values)
+			if labels := indexLabels(result); !valuesClose(labels, tt.labels) {
+				t.Errorf("expected index labels %v, got %v", tt.labels, labels)
+			}
+		})
+	}
+
+	t.Run("int and float give float", func(t *testing.T) {
+		result, err := dataframe.Concat(newDayFrames(), dataframe.ConcatRows, dataframe.ConcatOuter)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := result.Data[1].DType(); got != dataframe.FloatType {
+			t.Errorf("expected sales to be %s, got %s", dataframe.FloatType, got)
+		}
+		if got := result.Data[2].DType(); got != dataframe.IntType {
+			t.Errorf("expected returns to stay %s, got %s", dataframe.IntType, got)
+		}
+	})
+
+	t.Run("columns by label", func(t *testing.T) {
+		prices, err := (&dataframe.DataFrame{
+			Columns: []string{"ticker", "price"},
+			Data:    toSeries([][]any{{"MSFT", "GOOG", "AAPL"}, {410.5, 140.2, 185.0}}),
+		}).SetIndex("ticker")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		volumes, err := (&dataframe.DataFrame{
+			Columns: []string{"ticker", "volume"},
+			Data:    toSeries([][]any{{"AMZN", "MSFT", "GOOG"}, {900, 1200, 800}}),
+		}).SetIndex("ticker")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		outer, err := dataframe.Concat([]*dataframe.DataFrame{prices, volumes}, dataframe.ConcatColumns, dataframe.ConcatOuter)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkFrame(t, outer, []string{"price", "volume"}, [][]any{
+			{410.5, 140.2, 185.0, nil},
+			{int64(1200), int64(800), nil, int64(900)},
+		})
+		if labels, want := indexLabels(outer), []any{"MSFT", "GOOG", "AAPL", "AMZN"}; !valuesClose(labels, want) {
+			t.Errorf("expected index labels %v, got %v", want, labels)
+		}
+		if name := outer.RowIndex().Name(); name != "ticker" {
+			t.Errorf("expected the index to be named ticker, got %q", name)
+		}
+
+		inner, err := dataframe.Concat([]*dataframe.DataFrame{prices, volumes}, dataframe.ConcatColumns, dataframe.ConcatInner)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkFrame(t, inner, []string{"price", "volume"}, [][]any{
+			{410.5, 140.2},
+			{int64(1200), int64(800)},
+		})
+	})
+
+	duplicateLabels := &dataframe.DataFrame{
+		Columns: []string{"b"},
+		Data:    toSeries([][]any{{1, 2}}),
+		Index:   dataframe.NewLabelIndex("", dataframe.NewStringCol([]string{"x", "x"})),
+	}
+	single := &dataframe.DataFrame{Columns: []string{"a"}, Data: toSeries([][]any{{1, 2}})}
+	unhashableLabels := &dataframe.DataFrame{
+		Columns: []string{"b"},
+		Data:    toSeries([][]any{{1, 2}}),
+		Index:   dataframe.NewLabelIndex("", dataframe.NewObjectCol([]any{[]int{1}, "y"})),
+	}
+	errorTests := []struct {
+		name   string
+		frames []*dataframe.DataFrame
+		opts   dataframe.ConcatOptions
+		want   string
+	}{
+		{name: "no frames", want: "no DataFrames"},
+		{name: "nil frame", frames: []*dataframe.DataFrame{single, nil}, want: "DataFrame 1 is nil"},
+		{name: "invalid axis", frames: newDayFrames(), opts: dataframe.ConcatOptions{Axis: "diagonal"}, want: "invalid concat axis"},
+		{name: "invalid join", frames: newDayFrames(), opts: dataframe.ConcatOptions{Join: "left"}, want: "invalid concat join"},
+		{name: "key count", frames: newDayFrames(), opts: dataframe.ConcatOptions{Keys: []string{"mon"}}, want: "got 1 keys for 2 DataFrames"},
+		{name: "key column exists", frames: newDayFrames(), opts: dataframe.ConcatOptions{Keys: []string{"a", "b"}, KeyColumn: "sales"}, want: "already exists"},
+		{
+			name:   "keys on columns",
+			frames: []*dataframe.DataFrame{single},
+			opts:   dataframe.ConcatOptions{Axis: dataframe.ConcatColumns, Keys: []string{"a"}},
+			want:   "only be used when stacking rows",
+		},
+		{
+			name:   "repeated columns side by side",
+			frames: []*dataframe.DataFrame{single, single},
+			opts:   dataframe.ConcatOptions{Axis: dataframe.ConcatColumns},
+			want:   "more than once",
+		},
+		{
+			name:   "repeated labels",
+			frames: []*dataframe.DataFrame{single, duplicateLabels},
+			opts:   dataframe.ConcatOptions{Axis: dataframe.ConcatColumns},
+			want:   "index label x more than once",
+		},
+		{
+			name:   "unhashable labels",
+			frames: []*dataframe.DataFrame{single, unhashableLabels},
+			opts:   dataframe.ConcatOptions{Axis: dataframe.ConcatColumns},
+			want:   "DataFrame 1: the value at row 0 has the unhashable type []int",
+		},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := dataframe.ConcatWith(tt.frames, tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package dataframe_test
+
+import (
+	"gpandas/dataframe"
+	"math"
+	"math/rand"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// newCorrFrame returns a frame with an int, a float and a string column, the numeric
+// ones with nulls in different rows.
+func newCorrFrame() *dataframe.DataFrame {
+	return &dataframe.DataFrame{
+		Columns: []string{"x", "y", "label", "z"},
+		Data: toSeries([][]any{
+			{1, 2, 3, 4, 5, nil, 7},
+			{2.0, 4.0, 5.0, 4.0, 5.0, 7.0, nil},
+			{"a", "b", "c", "d", "e", "f", "g"},
+			{5, 4, 3, nil, 1, 0, 1},
+		}),
+	}
+}
+
+// TestCorr tests Corr and Cov.
+//
+// The test suite covers:
+//   - Pearson, Spearman and Kendall correlations and the covariance, over
+//     pairwise-complete rows and with ties
+//   - The string column left out and the column names labelling the rows
+//   - Constant columns and pairs with too few rows giving null
+//   - Kendall's tau-b on a large frame with ties against a direct pair count
+//   - Invalid methods
+func TestCorr(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix func(*dataframe.DataFrame) (*dataframe.DataFrame, error)
+		values [][]any
+	}{
+		{
+			name:   "pearson",
+			matrix: func(df *dataframe.DataFrame) (*dataframe.DataFrame, error) { return df.Corr(dataframe.CorrPearson) },
+			values: [][]any{
+				{1.0, 0.7745966692414834, -0.9516886081573908},
+				{0.7745966692414834, 1.0, -0.9158574812732756},
+				{-0.9516886081573908, -0.9158574812732756, 1.0},
+			},
+		},
+		{
+			name:   "spearman",
+			matrix: func(df *dataframe.DataFrame) (*dataframe.DataFrame, error) { return df.Corr(dataframe.CorrSpearman) },
+			values: [][]any{
+				{1.0, 0.7378647873726218, -0.9746794344808964},
+				{0.7378647873726218, 1.0, -0.9746794344808964},
+				{-0.9746794344808964, -0.9746794344808964, 1.0},
+			},
+		},
+		{
+			name:   "kendall",
+			matrix: func(df *dataframe.DataFrame) (*dataframe.DataFrame, error) { return df.Corr(dataframe.CorrKendall) },
+			values: [][]any{
+				{1.0, 0.6708203932499369, -0.9486832980505138},
+				{0.6708203932499369, 1.0, -0.9486832980505138},
+				{-0.9486832980505138, -0.9486832980505138, 1.0},
+			},
+		},
+		{
+			name:   "cov",
+			matrix: (*dataframe.DataFrame).Cov,
+			values: [][]any{
+				{4.666666666666667, 1.5, -4.1},
+				{1.5, 2.7, -3.45},
+				{-4.1, -3.45, 3.866666666666667},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matrix, err := tt.matrix(newCorrFrame())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			checkFrame(t, matrix, []string{"x", "y", "z"}, tt.values)
+			if labels, want := indexLabels(matrix), []any{"x", "y", "z"}; !valuesClose(labels, want) {
+				t.Errorf("expected labels %v, got %v", want, labels)
+			}
+			if name := matrix.RowIndex().Name(); name != dataframe.MatrixIndexName {
+				t.Errorf("expected the index to be named %s, got %q", dataframe.MatrixIndexName, name)
+			}
+		})
+	}
+
+	t.Run("undefined", func(t *testing.T) {
+		df := &dataframe.DataFrame{
+			Columns: []string{"a", "flat", "sparse"},
+			Data:    toSeries([][]any{{1.0, 2.0, 3.0}, {4, 4, 4}, {nil, 1.0, nil}}),
+		}
+		for _, method := range []dataframe.CorrMethod{dataframe.CorrPearson, dataframe.CorrSpearman, dataframe.CorrKendall} {
+			matrix, err := df.Corr(method)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			checkFrame(t, matrix, []string{"a", "flat", "sparse"}, [][]any{{1.0, nil, nil}, {nil, nil, nil}, {nil, nil, nil}})
+		}
+	})
+
+	t.Run("kendall ties", func(t *testing.T) {
+		// run the column pairs on several goroutines even on a single CPU
+		defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+		rng := rand.New(rand.NewSource(24))
+		const rows = 400
+		x, y := make([]any, rows), make([]any, rows)
+		xs, ys := make([]float64, rows), make([]float64, rows)
+		for i := range x {
+			xs[i] = float64(rng.Intn(10))
+			ys[i] = xs[i] + float64(rng.Intn(8))
+			x[i], y[i] = xs[i], ys[i]
+		}
+		df := &dataframe.DataFrame{Columns: []string{"x", "y"}, Data: toSeries([][]any{x, y})}
+		matrix, err := df.Corr(dataframe.CorrKendall)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var concordant, discordant, tiedX, tiedY float64
+		for i := 0; i < rows; i++ {
+			for j := i + 1; j < rows; j++ {
+				dx, dy := xs[i]-xs[j], ys[i]-ys[j]
+				switch {
+				case dx == 0 && dy == 0:
+				case dx == 0:
+					tiedX++
+				case dy == 0:
+					tiedY++
+				case dx*dy > 0:
+					concordant++
+				default:
+					discordant++
+				}
+			}
+		}
+		want := (concordant - discordant) / math.Sqrt((concordant+discordant+tiedY)*(concordant+discordant+tiedX))
+		if got := matrix.Data[1].At(0); !valuesClose([]any{got}, []any{want}) {
+			t.Errorf("expected tau %v, got %v", want, got)
+		}
+	})
+
+	t.Run("invalid method", func(t *testing.T) {
+		_, err := newCorrFrame().Corr("distance")
+		if err == nil || !strings.Contains(err.Error(), "invalid correlation method") {
+			t.Errorf("expected an error for an invalid method, got %v", err)
+		}
+	})
+}
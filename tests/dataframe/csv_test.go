@@ -0,0 +1,156 @@
+package dataframe_test
+
+import (
+	"bytes"
+	"errors"
+	"gpandas/dataframe"
+	"testing"
+)
+
+// failingWriter is an io.Writer that always fails.
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+// TestWriteCSV tests the ToCSVOptions accepted by DataFrame.WriteCSV.
+//
+// The test suite covers:
+//   - RFC 4180 quoting of separators, quotes, line breaks and leading spaces
+//   - The QuoteAll, QuoteNonNumeric and QuoteNone modes
+//   - Custom line terminators and separators
+//   - Omitting the header, writing the row index and selecting columns
+//   - Null representation and float formatting
+//   - Invalid options and writer errors
+func TestWriteCSV(t *testing.T) {
+	df := &dataframe.DataFrame{
+		Columns: []string{"name", "score", "n"},
+		Data: []dataframe.Series{
+			dataframe.NewStringCol([]string{`Smith, "Jr"`, "line\nbreak", " lead"}),
+			toSeries([][]any{{1.25, nil, 3.0}})[0],
+			dataframe.NewIntCol([]int64{1, 2, 3}),
+		},
+	}
+	simple := &dataframe.DataFrame{
+		Columns: []string{"a", "b"},
+		Data:    toSeries([][]any{{"x", nil}, {1.5, 2.0}}),
+	}
+
+	tests := []struct {
+		name        string
+		df          *dataframe.DataFrame
+		opts        dataframe.ToCSVOptions
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "minimal quoting",
+			df:       df,
+			expected: "name,score,n\n\"Smith, \"\"Jr\"\"\",1.25,1\n\"line\nbreak\",,2\n\" lead\",3,3\n",
+		},
+		{
+			name:     "quote all",
+			df:       simple,
+			opts:     dataframe.ToCSVOptions{Quoting: dataframe.QuoteAll},
+			expected: "\"a\",\"b\"\n\"x\",\"1.5\"\n\"\",\"2\"\n",
+		},
+		{
+			name:     "quote non-numeric",
+			df:       simple,
+			opts:     dataframe.ToCSVOptions{Quoting: dataframe.QuoteNonNumeric, NullRep: "NA"},
+			expected: "\"a\",\"b\"\n\"x\",1.5\nNA,2\n",
+		},
+		{
+			name:     "quote none",
+			df:       simple,
+			opts:     dataframe.ToCSVOptions{Quoting: dataframe.QuoteNone},
+			expected: "a,b\nx,1.5\n,2\n",
+		},
+		{
+			name:        "quote none with separator in field",
+			df:          df,
+			opts:        dataframe.ToCSVOptions{Quoting: dataframe.QuoteNone},
+			expectError: true,
+		},
+		{
+			name:     "separator and line terminator",
+			df:       simple,
+			opts:     dataframe.ToCSVOptions{Separator: "\t", LineTerminator: "\r\n"},
+			expected: "a\tb\r\nx\t1.5\r\n\t2\r\n",
+		},
+		{
+			name:     "multi-character separator",
+			df:       simple,
+			opts:     dataframe.ToCSVOptions{Separator: "::"},
+			expected: "a::b\nx::1.5\n::2\n",
+		},
+		{
+			name:     "omit header with index",
+			df:       simple,
+			opts:     dataframe.ToCSVOptions{OmitHeader: true, Index: true},
+			expected: "0,x,1.5\n1,,2\n",
+		},
+		{
+			name:     "index label",
+			df:       simple,
+			opts:     dataframe.ToCSVOptions{Index: true, IndexLabel: "row"},
+			expected: "row,a,b\n0,x,1.5\n1,,2\n",
+		},
+		{
+			name:     "column subset in given order",
+			df:       df,
+			opts:     dataframe.ToCSVOptions{Columns: []string{"n", "score"}},
+			expected: "n,score\n1,1.25\n2,\n3,3\n",
+		},
+		{
+			name:        "unknown column",
+			df:          df,
+			opts:        dataframe.ToCSVOptions{Columns: []string{"missing"}},
+			expectError: true,
+		},
+		{
+			name:     "float format",
+			df:       df,
+			opts:     dataframe.ToCSVOptions{Columns: []string{"score"}, FloatFormat: "%.3f", NullRep: "NaN"},
+			expected: "score\n1.250\nNaN\n3.000\n",
+		},
+		{
+			name:        "invalid separator",
+			df:          simple,
+			opts:        dataframe.ToCSVOptions{Separator: `"`},
+			expectError: true,
+		},
+		{
+			name:        "invalid quoting mode",
+			df:          simple,
+			opts:        dataframe.ToCSVOptions{Quoting: "some"},
+			expectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := test.df.WriteCSV(&buf, test.opts)
+			if test.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buf.String() != test.expected {
+				t.Errorf("CSV output mismatch\nexpected:\n%q\ngot:\n%q", test.expected, buf.String())
+			}
+		})
+	}
+
+	t.Run("writer error", func(t *testing.T) {
+		if err := df.WriteCSV(failingWriter{}, dataframe.ToCSVOptions{}); err == nil {
+			t.Error("expected error but got none")
+		}
+	})
+}
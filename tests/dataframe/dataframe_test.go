@@ -6,19 +6,37 @@ import (
 	"testing"
 )
 
-// Helper function to compare slices
-func sliceEqual(a, b []any) bool {
-	if len(a) != len(b) {
+// Helper function to build column-major Series from boxed column values
+func toSeries(columns [][]any) []dataframe.Series {
+	series := make([]dataframe.Series, len(columns))
+	for i, col := range columns {
+		series[i] = dataframe.NewSeries(col)
+	}
+	return series
+}
+
+// Helper function to compare Series value by value
+func seriesEqual(a, b dataframe.Series) bool {
+	if a.Len() != b.Len() {
 		return false
 	}
-	for i := range a {
-		if a[i] != b[i] {
+	for i := 0; i < a.Len(); i++ {
+		if a.At(i) != b.At(i) {
 			return false
 		}
 	}
 	return true
 }
 
+// Helper function to render a Series as a slice for error messages
+func seriesValues(s dataframe.Series) []any {
+	values := make([]any, s.Len())
+	for i := range values {
+		values[i] = s.At(i)
+	}
+	return values
+}
+
 // Helper function to compare string slices
 func strSliceEqual(a, b []string) bool {
 	if len(a) != len(b) {
@@ -67,7 +85,7 @@ func TestDataFrameRename(t *testing.T) {
 			name: "successful rename",
 			df: &dataframe.DataFrame{
 				Columns: []string{"A", "B", "C"},
-				Data:    [][]any{{1, 2, 3}, {4, 5, 6}},
+				Data:    toSeries([][]any{{1, 4}, {2, 5}, {3, 6}}),
 			},
 			columns:     map[string]string{"A": "X", "B": "Y"},
 			expectError: false,
@@ -76,7 +94,7 @@ func TestDataFrameRename(t *testing.T) {
 			name: "rename non-existent column",
 			df: &dataframe.DataFrame{
 				Columns: []string{"A", "B", "C"},
-				Data:    [][]any{{1, 2, 3}, {4, 5, 6}},
+				Data:    toSeries([][]any{{1, 4}, {2, 5}, {3, 6}}),
 			},
 			columns:     map[string]string{"D": "X"},
 			expectError: true,
@@ -91,7 +109,7 @@ func TestDataFrameRename(t *testing.T) {
 			name: "empty columns map",
 			df: &dataframe.DataFrame{
 				Columns: []string{"A", "B", "C"},
-				Data:    [][]any{{1, 2, 3}, {4, 5, 6}},
+				Data:    toSeries([][]any{{1, 4}, {2, 5}, {3, 6}}),
 			},
 			columns:     map[string]string{},
 			expectError: true,
@@ -155,7 +173,7 @@ func TestDataFrameRename(t *testing.T) {
 //	    name: "basic dataframe",
 //	    df: &dataframe.DataFrame{
 //	        Columns: []string{"A", "B", "C"},
-//	        Data:    [][]any{{1, 2, 3}, {4, 5, 6}},
+//	        Data:    toSeries([][]any{{1, 4}, {2, 5}, {3, 6}}),
 //	    },
 //	    expected: `+---+---+---+
 //	               | A | B | C |
@@ -176,7 +194,7 @@ func TestDataFrameString(t *testing.T) {
 			name: "basic dataframe",
 			df: &dataframe.DataFrame{
 				Columns: []string{"A", "B", "C"},
-				Data:    [][]any{{1, 2, 3}, {4, 5, 6}},
+				Data:    toSeries([][]any{{1, 4}, {2, 5}, {3, 6}}),
 			},
 			expected: `+---+---+---+
 | A | B | C |
@@ -191,7 +209,7 @@ func TestDataFrameString(t *testing.T) {
 			name: "empty dataframe",
 			df: &dataframe.DataFrame{
 				Columns: []string{"A", "B"},
-				Data:    [][]any{},
+				Data:    toSeries([][]any{}),
 			},
 			expected: `+---+---+
 | A | B |
@@ -204,7 +222,7 @@ func TestDataFrameString(t *testing.T) {
 			name: "mixed data types",
 			df: &dataframe.DataFrame{
 				Columns: []string{"Name", "Age", "Active"},
-				Data:    [][]any{{"John", 30, true}, {"Jane", 25, false}},
+				Data:    toSeries([][]any{{"John", "Jane"}, {30, 25}, {true, false}}),
 			},
 			expected: `+------+-----+--------+
 | Name | Age | Active |
@@ -307,8 +325,8 @@ func TestDataFrameString(t *testing.T) {
 //
 // 2. Success cases:
 //   - Verifies column names match expected output
-//   - Checks number of rows matches expected output
-//   - Validates each row's data matches expected values
+//   - Checks number of columns matches expected output
+//   - Validates each column's data matches expected values
 //
 // Example test case:
 //
@@ -316,17 +334,17 @@ func TestDataFrameString(t *testing.T) {
 //	    name: "inner merge - basic case",
 //	    df1: &dataframe.DataFrame{
 //	        Columns: []string{"ID", "Name"},
-//	        Data:    [][]any{{1, "Alice"}, {2, "Bob"}, {3, "Charlie"}},
+//	        Data:    toSeries([][]any{{1, 2, 3}, {"Alice", "Bob", "Charlie"}}),
 //	    },
 //	    df2: &dataframe.DataFrame{
 //	        Columns: []string{"ID", "Age"},
-//	        Data:    [][]any{{1, 25}, {2, 30}, {4, 35}},
+//	        Data:    toSeries([][]any{{1, 2, 4}, {25, 30, 35}}),
 //	    },
 //	    on:  "ID",
 //	    how: dataframe.InnerMerge,
 //	    expected: &dataframe.DataFrame{
 //	        Columns: []string{"ID", "Name", "Age"},
-//	        Data:    [][]any{{1, "Alice", 25}, {2, "Bob", 30}},
+//	        Data:    toSeries([][]any{{1, 2}, {"Alice", "Bob"}, {25, 30}}),
 //	    },
 //	    expectError: false,
 //	}
@@ -344,17 +362,17 @@ func TestDataFrameMerge(t *testing.T) {
 			name: "inner merge - basic case",
 			df1: &dataframe.DataFrame{
 				Columns: []string{"ID", "Name"},
-				Data:    [][]any{{1, "Alice"}, {2, "Bob"}, {3, "Charlie"}},
+				Data:    toSeries([][]any{{1, 2, 3}, {"Alice", "Bob", "Charlie"}}),
 			},
 			df2: &dataframe.DataFrame{
 				Columns: []string{"ID", "Age"},
-				Data:    [][]any{{1, 25}, {2, 30}, {4, 35}},
+				Data:    toSeries([][]any{{1, 2, 4}, {25, 30, 35}}),
 			},
 			on:  "ID",
 			how: dataframe.InnerMerge,
 			expected: &dataframe.DataFrame{
 				Columns: []string{"ID", "Name", "Age"},
-				Data:    [][]any{{1, "Alice", 25}, {2, "Bob", 30}},
+				Data:    toSeries([][]any{{1, 2}, {"Alice", "Bob"}, {25, 30}}),
 			},
 			expectError: false,
 		},
@@ -362,17 +380,17 @@ func TestDataFrameMerge(t *testing.T) {
 			name: "left merge - keep all left rows",
 			df1: &dataframe.DataFrame{
 				Columns: []string{"ID", "Name"},
-				Data:    [][]any{{1, "Alice"}, {2, "Bob"}, {3, "Charlie"}},
+				Data:    toSeries([][]any{{1, 2, 3}, {"Alice", "Bob", "Charlie"}}),
 			},
 			df2: &dataframe.DataFrame{
 				Columns: []string{"ID", "Age"},
-				Data:    [][]any{{1, 25}, {2, 30}},
+				Data:    toSeries([][]any{{1, 2}, {25, 30}}),
 			},
 			on:  "ID",
 			how: dataframe.LeftMerge,
 			expected: &dataframe.DataFrame{
 				Columns: []string{"ID", "Name", "Age"},
-				Data:    [][]any{{1, "Alice", 25}, {2, "Bob", 30}, {3, "Charlie", nil}},
+				Data:    toSeries([][]any{{1, 2, 3}, {"Alice", "Bob", "Charlie"}, {25, 30, nil}}),
 			},
 			expectError: false,
 		},
@@ -380,17 +398,17 @@ func TestDataFrameMerge(t *testing.T) {
 			name: "right merge - keep all right rows",
 			df1: &dataframe.DataFrame{
 				Columns: []string{"ID", "Name"},
-				Data:    [][]any{{1, "Alice"}, {2, "Bob"}},
+				Data:    toSeries([][]any{{1, 2}, {"Alice", "Bob"}}),
 			},
 			df2: &dataframe.DataFrame{
 				Columns: []string{"ID", "Age"},
-				Data:    [][]any{{1, 25}, {2, 30}, {3, 35}},
+				Data:    toSeries([][]any{{1, 2, 3}, {25, 30, 35}}),
 			},
 			on:  "ID",
 			how: dataframe.RightMerge,
 			expected: &dataframe.DataFrame{
 				Columns: []string{"ID", "Name", "Age"},
-				Data:    [][]any{{1, "Alice", 25}, {2, "Bob", 30}, {3, nil, 35}},
+				Data:    toSeries([][]any{{1, 2, 3}, {"Alice", "Bob", nil}, {25, 30, 35}}),
 			},
 			expectError: false,
 		},
@@ -398,17 +416,17 @@ func TestDataFrameMerge(t *testing.T) {
 			name: "full merge - keep all rows",
 			df1: &dataframe.DataFrame{
 				Columns: []string{"ID", "Name"},
-				Data:    [][]any{{1, "Alice"}, {2, "Bob"}, {3, "Charlie"}},
+				Data:    toSeries([][]any{{1, 2, 3}, {"Alice", "Bob", "Charlie"}}),
 			},
 			df2: &dataframe.DataFrame{
 				Columns: []string{"ID", "Age"},
-				Data:    [][]any{{1, 25}, {2, 30}, {4, 35}},
+				Data:    toSeries([][]any{{1, 2, 4}, {25, 30, 35}}),
 			},
 			on:  "ID",
 			how: dataframe.FullMerge,
 			expected: &dataframe.DataFrame{
 				Columns: []string{"ID", "Name", "Age"},
-				Data:    [][]any{{1, "Alice", 25}, {2, "Bob", 30}, {3, "Charlie", nil}, {4, nil, 35}},
+				Data:    toSeries([][]any{{1, 2, 3, 4}, {"Alice", "Bob", "Charlie", nil}, {25, 30, nil, 35}}),
 			},
 			expectError: false,
 		},
@@ -424,11 +442,11 @@ func TestDataFrameMerge(t *testing.T) {
 			name: "column not found error",
 			df1: &dataframe.DataFrame{
 				Columns: []string{"ID", "Name"},
-				Data:    [][]any{{1, "Alice"}},
+				Data:    toSeries([][]any{{1}, {"Alice"}}),
 			},
 			df2: &dataframe.DataFrame{
 				Columns: []string{"UserID", "Age"},
-				Data:    [][]any{{1, 25}},
+				Data:    toSeries([][]any{{1}, {25}}),
 			},
 			on:          "ID",
 			how:         dataframe.InnerMerge,
@@ -438,11 +456,11 @@ func TestDataFrameMerge(t *testing.T) {
 			name: "invalid merge type error",
 			df1: &dataframe.DataFrame{
 				Columns: []string{"ID", "Name"},
-				Data:    [][]any{{1, "Alice"}},
+				Data:    toSeries([][]any{{1}, {"Alice"}}),
 			},
 			df2: &dataframe.DataFrame{
 				Columns: []string{"ID", "Age"},
-				Data:    [][]any{{1, 25}},
+				Data:    toSeries([][]any{{1}, {25}}),
 			},
 			on:          "ID",
 			how:         "invalid",
@@ -471,15 +489,15 @@ func TestDataFrameMerge(t *testing.T) {
 				t.Errorf("columns mismatch\nexpected: %v\ngot: %v", test.expected.Columns, result.Columns)
 			}
 
-			// Check data matches
+			// Check data matches (column-major)
 			if len(result.Data) != len(test.expected.Data) {
 				t.Errorf("data length mismatch\nexpected: %d\ngot: %d", len(test.expected.Data), len(result.Data))
 				return
 			}
 
-			for i, row := range result.Data {
-				if !sliceEqual(row, test.expected.Data[i]) {
-					t.Errorf("row %d mismatch\nexpected: %v\ngot: %v", i, test.expected.Data[i], row)
+			for i, col := range result.Data {
+				if !seriesEqual(col, test.expected.Data[i]) {
+					t.Errorf("column %d mismatch\nexpected: %v\ngot: %v", i, seriesValues(test.expected.Data[i]), seriesValues(col))
 				}
 			}
 		})
@@ -523,7 +541,7 @@ func TestDataFrameToCSV(t *testing.T) {
 			name: "basic csv string output",
 			df: &dataframe.DataFrame{
 				Columns: []string{"A", "B", "C"},
-				Data:    [][]any{{1, 2, 3}, {4, 5, 6}},
+				Data:    toSeries([][]any{{1, 4}, {2, 5}, {3, 6}}),
 			},
 			filepath:    "",
 			expected:    "A,B,C\n1,2,3\n4,5,6\n",
@@ -533,7 +551,7 @@ func TestDataFrameToCSV(t *testing.T) {
 			name: "custom separator",
 			df: &dataframe.DataFrame{
 				Columns: []string{"A", "B", "C"},
-				Data:    [][]any{{1, 2, 3}, {4, 5, 6}},
+				Data:    toSeries([][]any{{1, 4}, {2, 5}, {3, 6}}),
 			},
 			filepath:    "",
 			separator:   ";",
@@ -544,7 +562,7 @@ func TestDataFrameToCSV(t *testing.T) {
 			name: "mixed data types",
 			df: &dataframe.DataFrame{
 				Columns: []string{"Name", "Age", "Active"},
-				Data:    [][]any{{"John", 30, true}, {"Jane", 25, false}},
+				Data:    toSeries([][]any{{"John", "Jane"}, {30, 25}, {true, false}}),
 			},
 			filepath:    "",
 			expected:    "Name,Age,Active\nJohn,30,true\nJane,25,false\n",
@@ -560,7 +578,7 @@ func TestDataFrameToCSV(t *testing.T) {
 			name: "invalid file path",
 			df: &dataframe.DataFrame{
 				Columns: []string{"A", "B"},
-				Data:    [][]any{{1, 2}},
+				Data:    toSeries([][]any{{1}, {2}}),
 			},
 			filepath:    "/nonexistent/directory/file.csv",
 			expectError: true,
@@ -613,7 +631,7 @@ func TestDataFrameToCSV(t *testing.T) {
 	t.Run("successful file writing", func(t *testing.T) {
 		df := &dataframe.DataFrame{
 			Columns: []string{"A", "B"},
-			Data:    [][]any{{1, 2}, {3, 4}},
+			Data:    toSeries([][]any{{1, 3}, {2, 4}}),
 		}
 		tempFile := t.TempDir() + "/test.csv"
 		expected := "A,B\n1,2\n3,4\n"
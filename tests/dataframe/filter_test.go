@@ -0,0 +1,227 @@
+package dataframe_test
+
+import (
+	"gpandas/dataframe"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newFilterFrame returns the frame shared by the filtering tests.
+func newFilterFrame() *dataframe.DataFrame {
+	return &dataframe.DataFrame{
+		Columns: []string{"name", "age", "score", "city"},
+		Data: toSeries([][]any{
+			{"Alice", "Bob", "Carol", "Dave", "Eve"},
+			{25, 35, nil, 42, 30},
+			{1.5, 2.5, 3.5, nil, 0.5},
+			{"Paris", "Berlin", "Paris", "Rome", nil},
+		}),
+	}
+}
+
+// TestSeriesCompare tests the comparison methods of Series.
+//
+// The test suite covers:
+//   - Every operator on int, float, string, datetime and bool columns
+//   - Mixed int and float comparisons
+//   - Comparisons with another Series
+//   - Nulls and incomparable types never matching
+//   - NaN, in the column, the scalar or the other Series, matching only Ne
+func TestSeriesCompare(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	ages := toSeries([][]any{{25, 35, nil, 42, 30}})[0]
+	nan := math.NaN()
+	floats := dataframe.NewFloatCol([]float64{1, nan, 3})
+
+	tests := []struct {
+		name     string
+		mask     dataframe.Mask
+		expected dataframe.Mask
+	}{
+		{"int gt", ages.Gt(30), dataframe.Mask{false, true, false, true, false}},
+		{"int ge", ages.Ge(30), dataframe.Mask{false, true, false, true, true}},
+		{"int lt", ages.Lt(30), dataframe.Mask{true, false, false, false, false}},
+		{"int le", ages.Le(30), dataframe.Mask{true, false, false, false, true}},
+		{"int eq", ages.Eq(30), dataframe.Mask{false, false, false, false, true}},
+		{"int ne skips nulls", ages.Ne(30), dataframe.Mask{true, true, false, true, false}},
+		{"int with float", ages.Gt(34.5), dataframe.Mask{false, true, false, true, false}},
+		{"int with int32", ages.Eq(int32(42)), dataframe.Mask{false, false, false, true, false}},
+		{"float with int", dataframe.NewFloatCol([]float64{1.5, 2, 2.5}).Ge(2), dataframe.Mask{false, true, true}},
+		{"string", dataframe.NewStringCol([]string{"a", "b", "c"}).Le("b"), dataframe.Mask{true, true, false}},
+		{"datetime", dataframe.NewDateTimeCol([]time.Time{day(1), day(2), day(3)}).Gt(day(1)), dataframe.Mask{false, true, true}},
+		{"bool", dataframe.NewBoolCol([]bool{true, false}).Gt(false), dataframe.Mask{true, false}},
+		{"object", dataframe.NewSeries([]any{1, "x", 2.5}).Gt(1), dataframe.Mask{false, false, true}},
+		{"incomparable types", ages.Gt("30"), dataframe.Mask{false, false, false, false, false}},
+		{"null scalar", ages.Eq(nil), dataframe.Mask{false, false, false, false, false}},
+		{"nan gt", floats.Gt(0), dataframe.Mask{true, false, true}},
+		{"nan ge", floats.Ge(0), dataframe.Mask{true, false, true}},
+		{"nan lt", floats.Lt(5), dataframe.Mask{true, false, true}},
+		{"nan le", floats.Le(5), dataframe.Mask{true, false, true}},
+		{"nan eq", floats.Eq(nan), dataframe.Mask{false, false, false}},
+		{"nan ne", floats.Ne(1), dataframe.Mask{false, true, true}},
+		{"nan scalar", floats.Lt(nan), dataframe.Mask{false, false, false}},
+		{"nan scalar ne", floats.Ne(nan), dataframe.Mask{true, true, true}},
+		{"int with nan", ages.Ge(nan), dataframe.Mask{false, false, false, false, false}},
+		{"int ne nan skips nulls", ages.Ne(nan), dataframe.Mask{true, true, false, true, true}},
+		{"object with nan", dataframe.NewSeries([]any{1.0, nan}).Le(1), dataframe.Mask{true, false}},
+		{"object ne nan", dataframe.NewSeries([]any{1.0, nan}).Ne(1), dataframe.Mask{false, true}},
+		{
+			"series with nan",
+			floats.Eq(dataframe.NewFloatCol([]float64{nan, nan, 3})),
+			dataframe.Mask{false, false, true},
+		},
+		{
+			"series ne nan",
+			floats.Ne(dataframe.NewFloatCol([]float64{nan, 2, 3})),
+			dataframe.Mask{true, true, false},
+		},
+		{
+			"series",
+			ages.Lt(toSeries([][]any{{30, 30, 30, nil, 31.5}})[0]),
+			dataframe.Mask{true, false, false, false, true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if !reflect.DeepEqual(test.mask, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, test.mask)
+			}
+		})
+	}
+}
+
+// TestMaskLogic tests And, Or, Not, Count and NewMask, and that And and Or panic on masks
+// of different lengths.
+func TestMaskLogic(t *testing.T) {
+	a := dataframe.Mask{true, true, false, false}
+	b := dataframe.Mask{true, false, true, false}
+	if got := a.And(b); !reflect.DeepEqual(got, dataframe.Mask{true, false, false, false}) {
+		t.Errorf("And: got %v", got)
+	}
+	if got := a.Or(b); !reflect.DeepEqual(got, dataframe.Mask{true, true, true, false}) {
+		t.Errorf("Or: got %v", got)
+	}
+	if got := a.Not(); !reflect.DeepEqual(got, dataframe.Mask{false, false, true, true}) {
+		t.Errorf("Not: got %v", got)
+	}
+	if a.Count() != 2 {
+		t.Errorf("Count: expected 2, got %d", a.Count())
+	}
+
+	mask, err := dataframe.NewMask(toSeries([][]any{{true, nil, false}})[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(mask, dataframe.Mask{true, false, false}) {
+		t.Errorf("NewMask: got %v", mask)
+	}
+	if _, err := dataframe.NewMask(dataframe.NewIntCol([]int64{1})); err == nil {
+		t.Error("expected error for non-bool Series")
+	}
+
+	short := dataframe.Mask{true, false}
+	for name, combine := range map[string]func(){
+		"And": func() { a.And(short) },
+		"Or":  func() { short.Or(a) },
+	} {
+		t.Run(name+" length mismatch", func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected %s to panic on masks of different lengths", name)
+				}
+			}()
+			combine()
+		})
+	}
+}
+
+// TestDataFrameFilter tests Filter, FilterMask, Where and Mask.
+func TestDataFrameFilter(t *testing.T) {
+	df := newFilterFrame()
+	age, _ := df.Col("age")
+	city, _ := df.Col("city")
+
+	t.Run("predicate", func(t *testing.T) {
+		result, err := df.Filter(func(row dataframe.Row) bool {
+			return row.Get("city") == "Paris" || row.IsNull("age")
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := seriesValues(result.Data[0]); !reflect.DeepEqual(got, []any{"Alice", "Carol"}) {
+			t.Errorf("expected Alice and Carol, got %v", got)
+		}
+	})
+
+	t.Run("predicate row index", func(t *testing.T) {
+		result, err := df.Filter(func(row dataframe.Row) bool { return row.Index()%2 == 0 })
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := seriesValues(result.Data[0]); !reflect.DeepEqual(got, []any{"Alice", "Carol", "Eve"}) {
+			t.Errorf("expected even rows, got %v", got)
+		}
+	})
+
+	t.Run("combined mask", func(t *testing.T) {
+		result, err := df.FilterMask(age.Ge(30).And(city.Eq("Rome").Not()))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := seriesValues(result.Data[0]); !reflect.DeepEqual(got, []any{"Bob", "Eve"}) {
+			t.Errorf("expected Bob and Eve, got %v", got)
+		}
+		if result.Data[1].DType() != dataframe.IntType {
+			t.Errorf("expected filtered age to stay int64, got %s", result.Data[1].DType())
+		}
+		if !result.Data[3].IsNull(1) {
+			t.Error("expected Eve's null city to stay null")
+		}
+	})
+
+	t.Run("where", func(t *testing.T) {
+		result, err := df.Where(age.Gt(30))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := []any{nil, int64(35), nil, int64(42), nil}
+		if got := seriesValues(result.Data[1]); !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+		if got := seriesValues(result.Data[0]); !reflect.DeepEqual(got, []any{nil, "Bob", nil, "Dave", nil}) {
+			t.Errorf("unexpected names: %v", got)
+		}
+	})
+
+	t.Run("mask", func(t *testing.T) {
+		result, err := df.Mask(age.Gt(30))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := seriesValues(result.Data[0]); !reflect.DeepEqual(got, []any{"Alice", nil, "Carol", nil, "Eve"}) {
+			t.Errorf("unexpected names: %v", got)
+		}
+	})
+
+	t.Run("mask length mismatch", func(t *testing.T) {
+		if _, err := df.FilterMask(dataframe.Mask{true}); err == nil {
+			t.Error("expected error for FilterMask")
+		}
+		if _, err := df.Where(dataframe.Mask{true}); err == nil {
+			t.Error("expected error for Where")
+		}
+		if _, err := df.Mask(dataframe.Mask{true}); err == nil {
+			t.Error("expected error for Mask")
+		}
+	})
+
+	t.Run("nil dataframe", func(t *testing.T) {
+		var nilDF *dataframe.DataFrame
+		if _, err := nilDF.Filter(func(dataframe.Row) bool { return true }); err == nil {
+			t.Error("expected error but got none")
+		}
+	})
+}
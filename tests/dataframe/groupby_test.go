@@ -0,0 +1,453 @@
+package dataframe_test
+
+import (
+	"errors"
+	"fmt"
+	"gpandas/dataframe"
+	"math"
+	"strings"
+	"testing"
+)
+
+// newGroupFrame returns the frame shared by the grouping tests. Grouped by city it holds
+// Berlin (rows 1 and 4), Paris (rows 0, 2 and 6) and Rome (row 3); row 5 has a null city.
+func newGroupFrame() *dataframe.DataFrame {
+	return &dataframe.DataFrame{
+		Columns: []string{"city", "product", "sales", "price"},
+		Data: toSeries([][]any{
+			{"Paris", "Berlin", "Paris", "Rome", "Berlin", nil, "Paris"},
+			{"a", "b", "b", "a", "b", "a", "a"},
+			{10, 20, 30, nil, 40, 70, 50},
+			{1.5, 2.5, nil, 4.0, 3.5, 9.0, 4.5},
+		}),
+	}
+}
+
+// valuesClose compares boxed values, allowing float64 values to differ by rounding. NaN
+// equals NaN.
+func valuesClose(got, want []any) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		g, gok := got[i].(float64)
+		w, wok := want[i].(float64)
+		if gok && wok {
+			if math.IsNaN(g) && math.IsNaN(w) {
+				continue
+			}
+			if math.Abs(g-w) > 1e-9 {
+				return false
+			}
+			continue
+		}
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// checkFrame compares the columns of df with the expected column values.
+func checkFrame(t *testing.T, df *dataframe.DataFrame, columns []string, values [][]any) {
+	t.Helper()
+	if !strSliceEqual(df.Columns, columns) {
+		t.Fatalf("expected columns %v, got %v", columns, df.Columns)
+	}
+	for i, want := range values {
+		if got := seriesValues(df.Data[i]); !valuesClose(got, want) {
+			t.Errorf("column %s: expected %v, got %v", columns[i], want, got)
+		}
+	}
+}
+
+// TestGroupBy tests the built-in aggregations of GroupedFrame.
+//
+// The test suite covers:
+//   - Every aggregation method on int, float and string columns with nulls
+//   - Groups sorted by key and rows with null keys left out
+//   - Numeric aggregations skipping non-numeric columns
+//   - Grouping by several keys
+//   - Invalid keys, and keys and NUnique values that cannot be hashed
+//   - NaN keys left out like null keys, sequentially and in parallel, and NaN values not
+//     counted by NUnique
+func TestGroupBy(t *testing.T) {
+	keys := []any{"Berlin", "Paris", "Rome"}
+	tests := []struct {
+		name      string
+		aggregate func(*dataframe.GroupedFrame) (*dataframe.DataFrame, error)
+		columns   []string
+		values    [][]any
+	}{
+		{
+			name:      "sum",
+			aggregate: (*dataframe.GroupedFrame).Sum,
+			columns:   []string{"city", "sales", "price"},
+			values:    [][]any{keys, {int64(60), int64(90), int64(0)}, {6.0, 6.0, 4.0}},
+		},
+		{
+			name:      "mean",
+			aggregate: (*dataframe.GroupedFrame).Mean,
+			columns:   []string{"city", "sales", "price"},
+			values:    [][]any{keys, {30.0, 30.0, nil}, {3.0, 3.0, 4.0}},
+		},
+		{
+			name:      "min",
+			aggregate: (*dataframe.GroupedFrame).Min,
+			columns:   []string{"city", "product", "sales", "price"},
+			values:    [][]any{keys, {"b", "a", "a"}, {int64(20), int64(10), nil}, {2.5, 1.5, 4.0}},
+		},
+		{
+			name:      "max",
+			aggregate: (*dataframe.GroupedFrame).Max,
+			columns:   []string{"city", "product", "sales", "price"},
+			values:    [][]any{keys, {"b", "b", "a"}, {int64(40), int64(50), nil}, {3.5, 4.5, 4.0}},
+		},
+		{
+			name:      "count",
+			aggregate: (*dataframe.GroupedFrame).Count,
+			columns:   []string{"city", "product", "sales", "price"},
+			values:    [][]any{keys, {int64(2), int64(3), int64(1)}, {int64(2), int64(3), int64(0)}, {int64(2), int64(2), int64(1)}},
+		},
+		{
+			name:      "std",
+			aggregate: (*dataframe.GroupedFrame).Std,
+			columns:   []string{"city", "sales", "price"},
+			values:    [][]any{keys, {math.Sqrt(200), 20.0, nil}, {math.Sqrt(0.5), math.Sqrt(4.5), nil}},
+		},
+		{
+			name:      "var",
+			aggregate: (*dataframe.GroupedFrame).Var,
+			columns:   []string{"city", "sales", "price"},
+			values:    [][]any{keys, {200.0, 400.0, nil}, {0.5, 4.5, nil}},
+		},
+		{
+			name:      "first",
+			aggregate: (*dataframe.GroupedFrame).First,
+			columns:   []string{"city", "product", "sales", "price"},
+			values:    [][]any{keys, {"b", "a", "a"}, {int64(20), int64(10), nil}, {2.5, 1.5, 4.0}},
+		},
+		{
+			name:      "last",
+			aggregate: (*dataframe.GroupedFrame).Last,
+			columns:   []string{"city", "product", "sales", "price"},
+			values:    [][]any{keys, {"b", "a", "a"}, {int64(40), int64(50), nil}, {3.5, 4.5, 4.0}},
+		},
+		{
+			name:      "nunique",
+			aggregate: (*dataframe.GroupedFrame).NUnique,
+			columns:   []string{"city", "product", "sales", "price"},
+			values:    [][]any{keys, {int64(1), int64(2), int64(1)}, {int64(2), int64(3), int64(0)}, {int64(2), int64(2), int64(1)}},
+		},
+		{
+			name:      "median",
+			aggregate: (*dataframe.GroupedFrame).Median,
+			columns:   []string{"city", "sales", "price"},
+			values:    [][]any{keys, {30.0, 30.0, nil}, {3.0, 3.0, 4.0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grouped, err := newGroupFrame().GroupBy("city")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if grouped.NGroups() != 3 {
+				t.Fatalf("expected 3 groups, got %d", grouped.NGroups())
+			}
+			result, err := tt.aggregate(grouped)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			checkFrame(t, result, tt.columns, tt.values)
+		})
+	}
+
+	t.Run("several keys", func(t *testing.T) {
+		grouped, err := newGroupFrame().GroupBy("city", "product")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		result, err := grouped.Sum()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkFrame(t, result, []string{"city", "product", "sales", "price"}, [][]any{
+			{"Berlin", "Paris", "Paris", "Rome"},
+			{"b", "a", "b", "a"},
+			{int64(60), int64(60), int64(30), int64(0)},
+			{6.0, 6.0, 0.0, 4.0},
+		})
+	})
+
+	t.Run("invalid keys", func(t *testing.T) {
+		df := newGroupFrame()
+		if _, err := df.GroupBy(); err == nil {
+			t.Error("expected error for no keys")
+		}
+		if _, err := df.GroupBy("country"); err == nil {
+			t.Error("expected error for a missing key")
+		}
+		if _, err := df.GroupBy("city", "city"); err == nil {
+			t.Error("expected error for a repeated key")
+		}
+	})
+
+	t.Run("unhashable values", func(t *testing.T) {
+		df := &dataframe.DataFrame{
+			Columns: []string{"tags", "n"},
+			Data: []dataframe.Series{
+				dataframe.NewObjectCol([]any{[]int{1}, "a", []int{1}}),
+				dataframe.NewIntCol([]int64{1, 2, 3}),
+			},
+		}
+		if _, err := df.GroupBy("tags"); err == nil || !strings.Contains(err.Error(), "unhashable type []int") {
+			t.Errorf("expected an unhashable key error, got %v", err)
+		}
+
+		grouped, err := df.GroupBy("n")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := grouped.NUnique(); err == nil {
+			t.Error("expected an error counting distinct unhashable values")
+		}
+	})
+
+	t.Run("nan keys", func(t *testing.T) {
+		nan := math.NaN()
+		for _, rows := range []int{6, 200_004} {
+			keys := make([]float64, rows)
+			objects := make([]any, rows)
+			for i := range keys {
+				keys[i] = []float64{1, nan, 2}[i%3]
+				objects[i] = keys[i]
+			}
+			df := &dataframe.DataFrame{
+				Columns: []string{"key", "object"},
+				Data:    []dataframe.Series{dataframe.NewFloatCol(keys), dataframe.NewObjectCol(objects)},
+			}
+			for _, key := range df.Columns {
+				grouped, err := df.GroupBy(key)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if grouped.NGroups() != 2 {
+					t.Errorf("%d rows by %s: expected NaN keys in no group and 2 groups, got %d", rows, key, grouped.NGroups())
+				}
+			}
+		}
+
+		values := dataframe.NewFloatCol([]float64{nan, nan, 1, nan, nan, nan, nan})
+		df := &dataframe.DataFrame{
+			Columns: []string{"city", "v"},
+			Data:    []dataframe.Series{dataframe.NewStringCol([]string{"a", "a", "a", "b", "b", "b", "b"}), values},
+		}
+		grouped, err := df.GroupBy("city")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		result, err := grouped.NUnique()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkFrame(t, result, []string{"city", "v"}, [][]any{{"a", "b"}, {int64(1), int64(0)}})
+	})
+
+	t.Run("parallel factorization", func(t *testing.T) {
+		const rows = 200_000
+		keys := make([]string, rows)
+		values := make([]int64, rows)
+		for i := range keys {
+			keys[i] = fmt.Sprintf("k%02d", (i*7)%13)
+			values[i] = 1
+		}
+		df := &dataframe.DataFrame{
+			Columns: []string{"key", "value"},
+			Data:    []dataframe.Series{dataframe.NewStringCol(keys), dataframe.NewIntCol(values)},
+		}
+		grouped, err := df.GroupBy("key")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		result, err := grouped.Sum()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Data[0].Len() != 13 {
+			t.Fatalf("expected 13 groups, got %d", result.Data[0].Len())
+		}
+		total := int64(0)
+		for i := 0; i < 13; i++ {
+			if key := result.Data[0].At(i); key != fmt.Sprintf("k%02d", i) {
+				t.Errorf("expected group %d to be k%02d, got %v", i, i, key)
+			}
+			total += result.Data[1].At(i).(int64)
+		}
+		if total != rows {
+			t.Errorf("expected group sizes to add up to %d, got %d", rows, total)
+		}
+	})
+}
+
+// TestGroupByAgg tests named multi-aggregation and custom aggregations.
+//
+// The test suite covers:
+//   - Agg naming result columns after the column and the aggregation
+//   - Custom aggregations built with NewAggFunc, alone and through Aggregate
+//   - Errors for missing columns, non-numeric columns and clashing names
+func TestGroupByAgg(t *testing.T) {
+	spread := dataframe.NewAggFunc("spread", func(values dataframe.Series) any {
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for i := 0; i < values.Len(); i++ {
+			if v, ok := values.At(i).(float64); ok {
+				lo, hi = math.Min(lo, v), math.Max(hi, v)
+			}
+		}
+		if lo > hi {
+			return nil
+		}
+		return hi - lo
+	})
+
+	grouped, err := newGroupFrame().GroupBy("city")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("named aggregations", func(t *testing.T) {
+		result, err := grouped.Agg(map[string][]dataframe.AggFunc{
+			"price":   {spread},
+			"sales":   {dataframe.AggSum, dataframe.AggMax},
+			"product": {dataframe.AggNUnique},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkFrame(t, result, []string{"city", "product_nunique", "sales_sum", "sales_max", "price_spread"}, [][]any{
+			{"Berlin", "Paris", "Rome"},
+			{int64(1), int64(2), int64(1)},
+			{int64(60), int64(90), int64(0)},
+			{int64(40), int64(50), nil},
+			{1.0, 3.0, 0.0},
+		})
+	})
+
+	t.Run("custom aggregate", func(t *testing.T) {
+		size := dataframe.NewAggFunc("size", func(values dataframe.Series) any {
+			return values.Len()
+		})
+		result, err := grouped.Aggregate(size)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkFrame(t, result, []string{"city", "product", "sales", "price"}, [][]any{
+			{"Berlin", "Paris", "Rome"},
+			{int64(2), int64(3), int64(1)},
+			{int64(2), int64(3), int64(1)},
+			{int64(2), int64(3), int64(1)},
+		})
+	})
+
+	errorTests := []struct {
+		name string
+		aggs map[string][]dataframe.AggFunc
+		want string
+	}{
+		{"missing column", map[string][]dataframe.AggFunc{"country": {dataframe.AggCount}}, "not present"},
+		{"non-numeric column", map[string][]dataframe.AggFunc{"product": {dataframe.AggMean}}, "cannot compute mean"},
+		{"clashing names", map[string][]dataframe.AggFunc{"sales": {dataframe.AggSum, dataframe.AggSum}}, "more than once"},
+		{"no aggregations", nil, "at least one"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := grouped.Agg(tt.aggs)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestGroupByApply tests Apply, Transform and Filter.
+//
+// The test suite covers:
+//   - Apply stacking per-group results with their index labels
+//   - Transform keeping the original row order and nulling rows without a group
+//   - Filter keeping whole groups in their original order
+//   - Errors returned by the callbacks
+func TestGroupByApply(t *testing.T) {
+	grouped, err := newGroupFrame().GroupBy("city")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("apply", func(t *testing.T) {
+		result, err := grouped.Apply(func(group *dataframe.DataFrame) (*dataframe.DataFrame, error) {
+			return group.Iloc(dataframe.Range(0, 1), dataframe.All())
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkFrame(t, result, []string{"city", "product", "sales", "price"}, [][]any{
+			{"Berlin", "Paris", "Rome"},
+			{"b", "a", "a"},
+			{int64(20), int64(10), nil},
+			{2.5, 1.5, 4.0},
+		})
+		if got := indexLabels(result); !valuesClose(got, []any{int64(1), int64(0), int64(3)}) {
+			t.Errorf("expected index labels [1 0 3], got %v", got)
+		}
+	})
+
+	t.Run("apply error", func(t *testing.T) {
+		_, err := grouped.Apply(func(*dataframe.DataFrame) (*dataframe.DataFrame, error) {
+			return nil, errors.New("boom")
+		})
+		if err == nil || !strings.Contains(err.Error(), "boom") {
+			t.Errorf("expected the callback error, got %v", err)
+		}
+	})
+
+	t.Run("transform", func(t *testing.T) {
+		result, err := grouped.Transform(func(values dataframe.Series) (dataframe.Series, error) {
+			return values.Take(make([]int, values.Len())), nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkFrame(t, result, []string{"product", "sales", "price"}, [][]any{
+			{"a", "b", "a", "a", "b", nil, "a"},
+			{int64(10), int64(20), int64(10), nil, int64(20), nil, int64(10)},
+			{1.5, 2.5, 1.5, 4.0, 2.5, nil, 1.5},
+		})
+	})
+
+	t.Run("transform length mismatch", func(t *testing.T) {
+		_, err := grouped.Transform(func(values dataframe.Series) (dataframe.Series, error) {
+			return values.Take([]int{0}), nil
+		})
+		if err == nil {
+			t.Error("expected error for a transform changing the group length")
+		}
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		result, err := grouped.Filter(func(group *dataframe.DataFrame) bool {
+			return group.RowIndex().Len() >= 2
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkFrame(t, result, []string{"city", "product", "sales", "price"}, [][]any{
+			{"Paris", "Berlin", "Paris", "Berlin", "Paris"},
+			{"a", "b", "b", "b", "a"},
+			{int64(10), int64(20), int64(30), int64(40), int64(50)},
+			{1.5, 2.5, nil, 3.5, 4.5},
+		})
+		if got := indexLabels(result); !valuesClose(got, []any{int64(0), int64(1), int64(2), int64(4), int64(6)}) {
+			t.Errorf("expected index labels [0 1 2 4 6], got %v", got)
+		}
+	})
+}
@@ -0,0 +1,322 @@
+package dataframe_test
+
+import (
+	"bytes"
+	"gpandas/dataframe"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newIndexFrame returns the frame shared by the indexing tests.
+func newIndexFrame() *dataframe.DataFrame {
+	return &dataframe.DataFrame{
+		Columns: []string{"name", "age", "city"},
+		Data: toSeries([][]any{
+			{"Alice", "Bob", "Carol", "Dave", "Eve"},
+			{25, 35, 41, 42, 30},
+			{"Paris", "Berlin", "Paris", "Rome", "Oslo"},
+		}),
+	}
+}
+
+// indexLabels returns every label of the index of df.
+func indexLabels(df *dataframe.DataFrame) []any {
+	index := df.RowIndex()
+	labels := make([]any, index.Len())
+	for i := range labels {
+		labels[i] = index.Label(i)
+	}
+	return labels
+}
+
+// TestIndex tests RangeIndex, LabelIndex, SetIndex, ResetIndex and index propagation.
+//
+// The test suite covers:
+//   - The default RangeIndex and label lookups across integer widths
+//   - SetIndex moving a column into the index and ResetIndex moving it back
+//   - Labels that cannot be hashed rejected instead of panicking
+//   - Filtering keeping the labels of the selected rows, and Where keeping the index
+//   - Rendering a named index in String and WriteCSV
+func TestIndex(t *testing.T) {
+	t.Run("default range index", func(t *testing.T) {
+		df := newIndexFrame()
+		index := df.RowIndex()
+		if _, ok := index.(dataframe.RangeIndex); !ok {
+			t.Fatalf("expected RangeIndex, got %T", index)
+		}
+		if index.Len() != 5 || index.Label(3) != int64(3) {
+			t.Errorf("unexpected index: len %d, label %v", index.Len(), index.Label(3))
+		}
+		if got := index.Positions(int32(2)); !reflect.DeepEqual(got, []int{2}) {
+			t.Errorf("expected position 2, got %v", got)
+		}
+		if got := index.Positions(7); got != nil {
+			t.Errorf("expected no positions, got %v", got)
+		}
+	})
+
+	t.Run("set and reset index", func(t *testing.T) {
+		df := newIndexFrame()
+		byCity, err := df.SetIndex("city")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strSliceEqual(byCity.Columns, []string{"name", "age"}) {
+			t.Errorf("expected city to leave the columns, got %v", byCity.Columns)
+		}
+		if got := byCity.RowIndex().Positions("Paris"); !reflect.DeepEqual(got, []int{0, 2}) {
+			t.Errorf("expected Paris at 0 and 2, got %v", got)
+		}
+		if len(df.Columns) != 3 {
+			t.Errorf("expected original DataFrame to keep its columns, got %v", df.Columns)
+		}
+
+		reset, err := byCity.ResetIndex(false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strSliceEqual(reset.Columns, []string{"city", "name", "age"}) || reset.Index != nil {
+			t.Errorf("expected city back as the first column, got %v with index %v", reset.Columns, reset.Index)
+		}
+		if !seriesEqual(reset.Data[0], df.Data[2]) {
+			t.Errorf("unexpected city values: %v", seriesValues(reset.Data[0]))
+		}
+
+		dropped, err := byCity.ResetIndex(true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strSliceEqual(dropped.Columns, []string{"name", "age"}) {
+			t.Errorf("expected labels to be dropped, got %v", dropped.Columns)
+		}
+
+		if _, err := df.SetIndex("missing"); err == nil {
+			t.Error("expected error for unknown column")
+		}
+	})
+
+	t.Run("unhashable labels", func(t *testing.T) {
+		df := &dataframe.DataFrame{
+			Columns: []string{"tags", "n"},
+			Data: []dataframe.Series{
+				dataframe.NewObjectCol([]any{[]int{1}, "a"}),
+				dataframe.NewIntCol([]int64{1, 2}),
+			},
+		}
+		if _, err := df.SetIndex("tags"); err == nil || !strings.Contains(err.Error(), "unhashable type []int") {
+			t.Errorf("expected an unhashable label error, got %v", err)
+		}
+
+		byN, err := df.SetIndex("n")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := byN.RowIndex().Positions([]int{1}); got != nil {
+			t.Errorf("expected an unhashable label to match nothing, got %v", got)
+		}
+		if _, err := byN.Loc(dataframe.Labels([]int{1}), nil); err == nil || !strings.Contains(err.Error(), "unhashable") {
+			t.Errorf("expected an unhashable label error, got %v", err)
+		}
+	})
+
+	t.Run("reset unnamed index", func(t *testing.T) {
+		df := newIndexFrame()
+		age, _ := df.Col("age")
+		filtered, _ := df.FilterMask(age.Gt(30))
+		reset, err := filtered.ResetIndex(false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if reset.Columns[0] != "index" || !reflect.DeepEqual(seriesValues(reset.Data[0]), []any{int64(1), int64(2), int64(3)}) {
+			t.Errorf("unexpected index column %s: %v", reset.Columns[0], seriesValues(reset.Data[0]))
+		}
+
+		clash := &dataframe.DataFrame{Columns: []string{"index"}, Data: toSeries([][]any{{1}})}
+		if _, err := clash.ResetIndex(false); err == nil {
+			t.Error("expected error for duplicate index column")
+		}
+	})
+
+	t.Run("filtering keeps labels", func(t *testing.T) {
+		df := newIndexFrame()
+		age, _ := df.Col("age")
+		filtered, err := df.FilterMask(age.Gt(30))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := indexLabels(filtered); !reflect.DeepEqual(got, []any{int64(1), int64(2), int64(3)}) {
+			t.Errorf("expected labels 1..3, got %v", got)
+		}
+		odd, err := df.Filter(func(row dataframe.Row) bool { return row.Index()%2 == 1 })
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := indexLabels(odd); !reflect.DeepEqual(got, []any{int64(1), int64(3)}) {
+			t.Errorf("expected labels 1 and 3, got %v", got)
+		}
+
+		byName, _ := df.SetIndex("name")
+		where, err := byName.Where(age.Gt(30))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if where.Index != byName.Index {
+			t.Error("expected Where to keep the index")
+		}
+	})
+
+	t.Run("named index is rendered", func(t *testing.T) {
+		df := newIndexFrame()
+		byName, _ := df.SetIndex("name")
+		if got := byName.String(); !strings.Contains(got, "| name  | age | city   |") || !strings.Contains(got, "| Alice | 25  | Paris  |") {
+			t.Errorf("expected index column in output, got:\n%s", got)
+		}
+
+		var buf bytes.Buffer
+		if err := byName.WriteCSV(&buf, dataframe.ToCSVOptions{Index: true}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.HasPrefix(buf.String(), "name,age,city\nAlice,25,Paris\n") {
+			t.Errorf("unexpected CSV:\n%s", buf.String())
+		}
+	})
+}
+
+// TestIlocLoc tests positional and label-based selection.
+func TestIlocLoc(t *testing.T) {
+	df := newIndexFrame()
+	byCity, _ := df.SetIndex("city")
+	age, _ := df.Col("age")
+
+	tests := []struct {
+		name        string
+		op          func() (*dataframe.DataFrame, error)
+		columns     []string
+		names       []any
+		labels      []any
+		expectError bool
+	}{
+		{
+			name:    "iloc range and positions",
+			op:      func() (*dataframe.DataFrame, error) { return df.Iloc(dataframe.Range(1, 3), dataframe.Positions(0)) },
+			columns: []string{"name"},
+			names:   []any{"Bob", "Carol"},
+			labels:  []any{int64(1), int64(2)},
+		},
+		{
+			name:    "iloc negative range",
+			op:      func() (*dataframe.DataFrame, error) { return df.Iloc(dataframe.Range(-2, math.MaxInt), nil) },
+			columns: []string{"name", "age", "city"},
+			names:   []any{"Dave", "Eve"},
+			labels:  []any{int64(3), int64(4)},
+		},
+		{
+			name: "iloc negative positions in given order",
+			op: func() (*dataframe.DataFrame, error) {
+				return df.Iloc(dataframe.Positions(-1, 0), dataframe.Positions(0))
+			},
+			columns: []string{"name"},
+			names:   []any{"Eve", "Alice"},
+			labels:  []any{int64(4), int64(0)},
+		},
+		{
+			name:    "iloc mask",
+			op:      func() (*dataframe.DataFrame, error) { return df.Iloc(age.Lt(30), dataframe.Range(0, 1)) },
+			columns: []string{"name"},
+			names:   []any{"Alice"},
+			labels:  []any{int64(0)},
+		},
+		{
+			name:        "iloc position out of range",
+			op:          func() (*dataframe.DataFrame, error) { return df.Iloc(dataframe.Positions(5), nil) },
+			expectError: true,
+		},
+		{
+			name:        "iloc rejects labels",
+			op:          func() (*dataframe.DataFrame, error) { return df.Iloc(dataframe.Labels(1), nil) },
+			expectError: true,
+		},
+		{
+			name: "loc duplicate labels",
+			op: func() (*dataframe.DataFrame, error) {
+				return byCity.Loc(dataframe.Labels("Paris"), dataframe.Labels("name"))
+			},
+			columns: []string{"name"},
+			names:   []any{"Alice", "Carol"},
+			labels:  []any{"Paris", "Paris"},
+		},
+		{
+			name: "loc label range is inclusive",
+			op: func() (*dataframe.DataFrame, error) {
+				return byCity.Loc(dataframe.LabelRange("Berlin", "Rome"), dataframe.LabelRange(nil, "name"))
+			},
+			columns: []string{"name"},
+			names:   []any{"Bob", "Carol", "Dave"},
+			labels:  []any{"Berlin", "Paris", "Rome"},
+		},
+		{
+			name:    "loc on range index",
+			op:      func() (*dataframe.DataFrame, error) { return df.Loc(dataframe.Labels(3, 1), dataframe.Labels("name")) },
+			columns: []string{"name"},
+			names:   []any{"Dave", "Bob"},
+			labels:  []any{int64(3), int64(1)},
+		},
+		{
+			name:        "loc missing label",
+			op:          func() (*dataframe.DataFrame, error) { return byCity.Loc(dataframe.Labels("Madrid"), nil) },
+			expectError: true,
+		},
+		{
+			name:        "loc missing column",
+			op:          func() (*dataframe.DataFrame, error) { return byCity.Loc(nil, dataframe.Labels("salary")) },
+			expectError: true,
+		},
+		{
+			name:        "loc rejects positions",
+			op:          func() (*dataframe.DataFrame, error) { return byCity.Loc(dataframe.Range(0, 2), nil) },
+			expectError: true,
+		},
+		{
+			name:        "mask length mismatch",
+			op:          func() (*dataframe.DataFrame, error) { return df.Iloc(dataframe.Mask{true}, nil) },
+			expectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := test.op()
+			if test.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strSliceEqual(result.Columns, test.columns) {
+				t.Errorf("expected columns %v, got %v", test.columns, result.Columns)
+			}
+			if got := seriesValues(result.Data[0]); !reflect.DeepEqual(got, test.names) {
+				t.Errorf("expected names %v, got %v", test.names, got)
+			}
+			if got := indexLabels(result); !reflect.DeepEqual(got, test.labels) {
+				t.Errorf("expected labels %v, got %v", test.labels, got)
+			}
+		})
+	}
+
+	t.Run("result is a copy", func(t *testing.T) {
+		result, err := df.Iloc(dataframe.Positions(0), dataframe.Positions(1))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		result.Data[0].(*dataframe.IntCol).SetNull(0)
+		if df.Data[1].IsNull(0) {
+			t.Error("expected modifying the result to leave the original unchanged")
+		}
+	})
+}
@@ -0,0 +1,1137 @@
+package dataframe_test
+
+import (
+	"cmp"
+	"gpandas/dataframe"
+	"math"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newOrdersFrame returns the left frame shared by the merge tests.
+func newOrdersFrame() *dataframe.DataFrame {
+	return &dataframe.DataFrame{
+		Columns: []string{"customer", "year", "total"},
+		Data: toSeries([][]any{
+			{"acme", "acme", "globex", "initech"},
+			{2023, 2024, 2024, 2024},
+			{100, 150, 80, 60},
+		}),
+	}
+}
+
+// newTargetsFrame returns the right frame shared by the merge tests.
+func newTargetsFrame() *dataframe.DataFrame {
+	return &dataframe.DataFrame{
+		Columns: []string{"client", "year", "goal"},
+		Data: toSeries([][]any{
+			{"acme", "globex", "acme", "umbrella"},
+			{2024, 2024, 2022, 2024},
+			{140, 90, 70, 50},
+		}),
+	}
+}
+
+// TestMergeWith tests merging on composite, differently named and index keys.
+//
+// The test suite covers:
+//   - Composite keys named by On, LeftOn and RightOn for every merge type
+//   - Shared key names coalesced into one column, other key columns kept on both sides
+//   - Merging on the index of one or both DataFrames
+//   - Invalid key combinations
+func TestMergeWith(t *testing.T) {
+	renamed := newTargetsFrame()
+	if err := renamed.Rename(map[string]string{"client": "customer"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		right   *dataframe.DataFrame
+		opts    dataframe.MergeOptions
+		columns []string
+		values  [][]any
+	}{
+		{
+			name:    "composite On inner",
+			right:   renamed,
+			opts:    dataframe.MergeOptions{On: []string{"customer", "year"}},
+			columns: []string{"customer", "year", "total", "goal"},
+			values: [][]any{
+				{"acme", "globex"},
+				{int64(2024), int64(2024)},
+				{int64(150), int64(80)},
+				{int64(140), int64(90)},
+			},
+		},
+		{
+			name:    "composite On full",
+			right:   renamed,
+			opts:    dataframe.MergeOptions{How: dataframe.FullMerge, On: []string{"customer", "year"}},
+			columns: []string{"customer", "year", "total", "goal"},
+			values: [][]any{
+				{"acme", "acme", "globex", "initech", "acme", "umbrella"},
+				{int64(2023), int64(2024), int64(2024), int64(2024), int64(2022), int64(2024)},
+				{int64(100), int64(150), int64(80), int64(60), nil, nil},
+				{nil, int64(140), int64(90), nil, int64(70), int64(50)},
+			},
+		},
+		{
+			name:  "LeftOn and RightOn left",
+			right: newTargetsFrame(),
+			opts: dataframe.MergeOptions{
+				How:     dataframe.LeftMerge,
+				LeftOn:  []string{"customer", "year"},
+				RightOn: []string{"client", "year"},
+			},
+			columns: []string{"customer", "year", "total", "client", "goal"},
+			values: [][]any{
+				{"acme", "acme", "globex", "initech"},
+				{int64(2023), int64(2024), int64(2024), int64(2024)},
+				{int64(100), int64(150), int64(80), int64(60)},
+				{nil, "acme", "globex", nil},
+				{nil, int64(140), int64(90), nil},
+			},
+		},
+		{
+			name:  "LeftOn and RightOn right",
+			right: newTargetsFrame(),
+			opts: dataframe.MergeOptions{
+				How:     dataframe.RightMerge,
+				LeftOn:  []string{"customer"},
+				RightOn: []string{"client"},
+			},
+			columns: []string{"customer", "year_x", "total", "client", "year_y", "goal"},
+			values: [][]any{
+				{"acme", "acme", "globex", "acme", "acme", nil},
+				{int64(2023), int64(2024), int64(2024), int64(2023), int64(2024), nil},
+				{int64(100), int64(150), int64(80), int64(100), int64(150), nil},
+				{"acme", "acme", "globex", "acme", "acme", "umbrella"},
+				{int64(2024), int64(2024), int64(2024), int64(2022), int64(2022), int64(2024)},
+				{int64(140), int64(140), int64(90), int64(70), int64(70), int64(50)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := newOrdersFrame().MergeWith(tt.right, tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			checkFrame(t, result, tt.columns, tt.values)
+		})
+	}
+
+	t.Run("index keys", func(t *testing.T) {
+		left, err := newOrdersFrame().SetIndex("customer")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		right, err := newTargetsFrame().SetIndex("client")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		both, err := left.MergeWith(right, dataframe.MergeOptions{
+			How:        dataframe.FullMerge,
+			LeftIndex:  true,
+			RightIndex: true,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := indexLabels(both); !valuesClose(got, []any{"acme", "acme", "acme", "acme", "globex", "initech", "umbrella"}) {
+			t.Errorf("unexpected index labels %v", got)
+		}
+		if both.RowIndex().Name() != "customer" {
+			t.Errorf("expected index name customer, got %q", both.RowIndex().Name())
+		}
+		checkFrame(t, both, []string{"year_x", "total", "year_y", "goal"}, [][]any{
+			{int64(2023), int64(2023), int64(2024), int64(2024), int64(2024), int64(2024), nil},
+			{int64(100), int64(100), int64(150), int64(150), int64(80), int64(60), nil},
+			{int64(2024), int64(2022), int64(2024), int64(2022), int64(2024), nil, int64(2024)},
+			{int64(140), int64(70), int64(140), int64(70), int64(90), nil, int64(50)},
+		})
+
+		mixed, err := left.MergeWith(newTargetsFrame(), dataframe.MergeOptions{
+			LeftIndex: true,
+			RightOn:   []string{"client"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := mixed.RowIndex().(dataframe.RangeIndex); !ok {
+			t.Errorf("expected a RangeIndex, got %T", mixed.RowIndex())
+		}
+		checkFrame(t, mixed, []string{"year_x", "total", "client", "year_y", "goal"}, [][]any{
+			{int64(2023), int64(2023), int64(2024), int64(2024), int64(2024)},
+			{int64(100), int64(100), int64(150), int64(150), int64(80)},
+			{"acme", "acme", "acme", "acme", "globex"},
+			{int64(2024), int64(2022), int64(2024), int64(2022), int64(2024)},
+			{int64(140), int64(70), int64(140), int64(70), int64(90)},
+		})
+	})
+
+	errorTests := []struct {
+		name string
+		opts dataframe.MergeOptions
+		want string
+	}{
+		{"no keys", dataframe.MergeOptions{}, "no left merge keys"},
+		{"On with LeftOn", dataframe.MergeOptions{On: []string{"year"}, LeftOn: []string{"year"}}, "cannot be combined"},
+		{"LeftOn with LeftIndex", dataframe.MergeOptions{LeftOn: []string{"year"}, LeftIndex: true, RightOn: []string{"year"}}, "LeftOn cannot be combined with LeftIndex"},
+		{"missing right keys", dataframe.MergeOptions{LeftOn: []string{"year"}}, "no right merge keys"},
+		{"key count mismatch", dataframe.MergeOptions{LeftOn: []string{"customer", "year"}, RightOn: []string{"client"}}, "differ in number"},
+		{"missing column", dataframe.MergeOptions{LeftOn: []string{"client"}, RightOn: []string{"client"}}, "left DataFrame"},
+		{"On missing on one side", dataframe.MergeOptions{On: []string{"customer"}}, "not found in both"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newOrdersFrame().MergeWith(newTargetsFrame(), tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestMergeCollisions tests suffixes, the collision error mode and key validation.
+//
+// The test suite covers:
+//   - Default and custom suffixes on columns present in both DataFrames
+//   - CollisionsError naming the colliding columns
+//   - Suffixed names clashing with existing columns
+//   - Every Validate mode on unique and duplicated keys
+func TestMergeCollisions(t *testing.T) {
+	byCustomer := dataframe.MergeOptions{LeftOn: []string{"customer"}, RightOn: []string{"client"}}
+
+	t.Run("default suffixes", func(t *testing.T) {
+		result, err := newOrdersFrame().MergeWith(newTargetsFrame(), byCustomer)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := []string{"customer", "year_x", "total", "client", "year_y", "goal"}
+		if !strSliceEqual(result.Columns, expected) {
+			t.Errorf("expected columns %v, got %v", expected, result.Columns)
+		}
+	})
+
+	t.Run("custom suffixes", func(t *testing.T) {
+		opts := byCustomer
+		opts.Suffixes = [2]string{"_order", ""}
+		result, err := newOrdersFrame().MergeWith(newTargetsFrame(), opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := []string{"customer", "year_order", "total", "client", "year", "goal"}
+		if !strSliceEqual(result.Columns, expected) {
+			t.Errorf("expected columns %v, got %v", expected, result.Columns)
+		}
+		if err := result.Rename(map[string]string{"year": "target_year"}); err != nil {
+			t.Errorf("expected suffixed columns to be renamable, got %v", err)
+		}
+	})
+
+	t.Run("shared keys do not collide", func(t *testing.T) {
+		_, err := newOrdersFrame().MergeWith(newOrdersFrame(), dataframe.MergeOptions{
+			On:          []string{"customer", "year"},
+			OnCollision: dataframe.CollisionsError,
+		})
+		if err == nil || !strings.HasSuffix(err.Error(), "columns present in both DataFrames: total") {
+			t.Errorf("expected a collision on total only, got %v", err)
+		}
+	})
+
+	t.Run("collision error", func(t *testing.T) {
+		opts := byCustomer
+		opts.OnCollision = dataframe.CollisionsError
+		_, err := newOrdersFrame().MergeWith(newTargetsFrame(), opts)
+		if err == nil || !strings.Contains(err.Error(), "year") {
+			t.Errorf("expected an error naming year, got %v", err)
+		}
+	})
+
+	t.Run("suffixed name already taken", func(t *testing.T) {
+		left := newOrdersFrame()
+		left.Columns[2] = "year_y"
+		_, err := left.MergeWith(newTargetsFrame(), byCustomer)
+		if err == nil || !strings.Contains(err.Error(), "year_y") {
+			t.Errorf("expected an error naming year_y, got %v", err)
+		}
+	})
+
+	validateTests := []struct {
+		name     string
+		validate dataframe.MergeValidate
+		right    *dataframe.DataFrame
+		want     string
+	}{
+		{"one to one fails on left", dataframe.ValidateOneToOne, newTargetsFrame(), "not unique in the left DataFrame: key (acme) appears in 2 rows, starting at rows 0 and 1"},
+		{"one to many fails on left", dataframe.ValidateOneToMany, newTargetsFrame(), "not a one_to_many merge"},
+		{"many to one fails on right", dataframe.ValidateManyToOne, newTargetsFrame(), "not unique in the right DataFrame: key (acme)"},
+		{"many to many accepts duplicates", dataframe.ValidateManyToMany, newTargetsFrame(), ""},
+		{
+			"many to one accepts unique right keys",
+			dataframe.ValidateManyToOne,
+			&dataframe.DataFrame{
+				Columns: []string{"client", "region"},
+				Data:    toSeries([][]any{{"acme", "globex"}, {"north", "south"}}),
+			},
+			"",
+		},
+		{"invalid mode", "some_to_some", newTargetsFrame(), "invalid merge validation"},
+	}
+	for _, tt := range validateTests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := byCustomer
+			opts.Validate = tt.validate
+			_, err := newOrdersFrame().MergeWith(tt.right, opts)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	t.Run("composite key in message", func(t *testing.T) {
+		right := &dataframe.DataFrame{
+			Columns: []string{"customer", "year"},
+			Data:    toSeries([][]any{{"acme", "acme"}, {2024, 2024}}),
+		}
+		_, err := newOrdersFrame().MergeWith(right, dataframe.MergeOptions{
+			On:       []string{"customer", "year"},
+			Validate: dataframe.ValidateOneToOne,
+		})
+		if err == nil || !strings.Contains(err.Error(), "key (acme, 2024)") {
+			t.Errorf("expected the composite key in the error, got %v", err)
+		}
+	})
+}
+
+// TestMergeSemiAntiCross tests the semi, anti and cross merge types and the indicator column.
+//
+// The test suite covers:
+//   - SemiMerge and AntiMerge keeping left rows, once each, with left columns only
+//   - CrossMerge pairing every row, with suffixes on shared column names
+//   - The _merge indicator for full, left, semi and anti merges
+//   - Errors for keys given to CrossMerge and an existing _merge column
+func TestMergeSemiAntiCross(t *testing.T) {
+	byCustomer := dataframe.MergeOptions{LeftOn: []string{"customer"}, RightOn: []string{"client"}}
+	withHow := func(how dataframe.MergeHow, indicator bool) dataframe.MergeOptions {
+		opts := byCustomer
+		opts.How = how
+		opts.Indicator = indicator
+		return opts
+	}
+
+	tests := []struct {
+		name    string
+		opts    dataframe.MergeOptions
+		columns []string
+		values  [][]any
+	}{
+		{
+			name:    "semi",
+			opts:    withHow(dataframe.SemiMerge, false),
+			columns: []string{"customer", "year", "total"},
+			values: [][]any{
+				{"acme", "acme", "globex"},
+				{int64(2023), int64(2024), int64(2024)},
+				{int64(100), int64(150), int64(80)},
+			},
+		},
+		{
+			name:    "anti with indicator",
+			opts:    withHow(dataframe.AntiMerge, true),
+			columns: []string{"customer", "year", "total", "_merge"},
+			values: [][]any{
+				{"initech"},
+				{int64(2024)},
+				{int64(60)},
+				{"left_only"},
+			},
+		},
+		{
+			name:    "semi with indicator",
+			opts:    withHow(dataframe.SemiMerge, true),
+			columns: []string{"customer", "year", "total", "_merge"},
+			values: [][]any{
+				{"acme", "acme", "globex"},
+				{int64(2023), int64(2024), int64(2024)},
+				{int64(100), int64(150), int64(80)},
+				{"both", "both", "both"},
+			},
+		},
+		{
+			name:    "full with indicator",
+			opts:    withHow(dataframe.FullMerge, true),
+			columns: []string{"customer", "year_x", "total", "client", "year_y", "goal", "_merge"},
+			values: [][]any{
+				{"acme", "acme", "acme", "acme", "globex", "initech", nil},
+				{int64(2023), int64(2023), int64(2024), int64(2024), int64(2024), int64(2024), nil},
+				{int64(100), int64(100), int64(150), int64(150), int64(80), int64(60), nil},
+				{"acme", "acme", "acme", "acme", "globex", nil, "umbrella"},
+				{int64(2024), int64(2022), int64(2024), int64(2022), int64(2024), nil, int64(2024)},
+				{int64(140), int64(70), int64(140), int64(70), int64(90), nil, int64(50)},
+				{"both", "both", "both", "both", "both", "left_only", "right_only"},
+			},
+		},
+		{
+			name:    "cross",
+			opts:    dataframe.MergeOptions{How: dataframe.CrossMerge},
+			columns: []string{"customer", "year_x", "total", "client", "year_y", "goal"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := newOrdersFrame().MergeWith(newTargetsFrame(), tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			checkFrame(t, result, tt.columns, tt.values)
+		})
+	}
+
+	t.Run("cross pairs every row", func(t *testing.T) {
+		result, err := newOrdersFrame().Merge(newTargetsFrame(), "", dataframe.CrossMerge)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Data[0].Len() != 16 {
+			t.Fatalf("expected 16 rows, got %d", result.Data[0].Len())
+		}
+		for i := 0; i < 16; i++ {
+			wantCustomer := newOrdersFrame().Data[0].At(i / 4)
+			wantClient := newTargetsFrame().Data[0].At(i % 4)
+			if result.Data[0].At(i) != wantCustomer || result.Data[3].At(i) != wantClient {
+				t.Errorf("row %d: expected (%v, %v), got (%v, %v)", i, wantCustomer, wantClient, result.Data[0].At(i), result.Data[3].At(i))
+			}
+		}
+	})
+
+	t.Run("cross with keys", func(t *testing.T) {
+		_, err := newOrdersFrame().MergeWith(newTargetsFrame(), withHow(dataframe.CrossMerge, false))
+		if err == nil || !strings.Contains(err.Error(), "no merge keys") {
+			t.Errorf("expected an error for keys on a cross merge, got %v", err)
+		}
+	})
+
+	t.Run("indicator column exists", func(t *testing.T) {
+		left := newOrdersFrame()
+		left.Columns[2] = "_merge"
+		_, err := left.MergeWith(newTargetsFrame(), withHow(dataframe.LeftMerge, true))
+		if err == nil || !strings.Contains(err.Error(), "_merge") {
+			t.Errorf("expected an error for an existing _merge column, got %v", err)
+		}
+	})
+}
+
+// TestMergeKeyNormalization tests that keys match by value across column types and that
+// null keys follow SQL semantics unless MatchNulls is set.
+//
+// The test suite covers:
+//   - int, int32 and integral float keys matching each other, []byte matching string
+//   - Keys that cannot be hashed rejected instead of panicking
+//   - Null keys never matching, and right rows with null keys kept by a full merge
+//   - MatchNulls pairing null keys, including composite keys with a null part
+//   - Composite keys matching by value, including struct parts and more than four columns
+func TestMergeKeyNormalization(t *testing.T) {
+	t.Run("numeric widths", func(t *testing.T) {
+		left := &dataframe.DataFrame{
+			Columns: []string{"id", "name"},
+			Data:    toSeries([][]any{{1, 2, 3}, {"a", "b", "c"}}),
+		}
+		right := &dataframe.DataFrame{
+			Columns: []string{"id", "score"},
+			Data:    toSeries([][]any{{int32(1), 2.0, 3.5}, {10, 20, 30}}),
+		}
+		result, err := left.Merge(right, "id", dataframe.InnerMerge)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkFrame(t, result, []string{"id", "name", "score"}, [][]any{
+			{int64(1), int64(2)},
+			{"a", "b"},
+			{int64(10), int64(20)},
+		})
+	})
+
+	t.Run("bytes and strings", func(t *testing.T) {
+		left := &dataframe.DataFrame{
+			Columns: []string{"code"},
+			Data:    []dataframe.Series{dataframe.NewObjectCol([]any{[]byte("x"), "y"})},
+		}
+		right := &dataframe.DataFrame{
+			Columns: []string{"code", "n"},
+			Data:    toSeries([][]any{{"x", "y"}, {1, 2}}),
+		}
+		result, err := left.Merge(right, "code", dataframe.InnerMerge)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := seriesValues(result.Data[1]); !valuesClose(got, []any{int64(1), int64(2)}) {
+			t.Errorf("expected both rows to match, got %v", got)
+		}
+	})
+
+	t.Run("unhashable keys", func(t *testing.T) {
+		df := &dataframe.DataFrame{
+			Columns: []string{"tags", "n"},
+			Data: []dataframe.Series{
+				dataframe.NewObjectCol([]any{[]int{1}, "a"}),
+				dataframe.NewIntCol([]int64{1, 2}),
+			},
+		}
+		if _, err := df.Merge(df, "tags", dataframe.InnerMerge); err == nil || !strings.Contains(err.Error(), "unhashable type []int") {
+			t.Errorf("expected an unhashable key error, got %v", err)
+		}
+		df.Index = dataframe.NewLabelIndex("tags", df.Data[0])
+		if _, err := df.MergeWith(df, dataframe.MergeOptions{LeftIndex: true, RightOn: []string{"n"}}); err == nil || !strings.Contains(err.Error(), "left index") {
+			t.Errorf("expected an unhashable index error, got %v", err)
+		}
+	})
+
+	left := &dataframe.DataFrame{
+		Columns: []string{"k", "l"},
+		Data:    toSeries([][]any{{1, nil, 2}, {"a", "b", "c"}}),
+	}
+	right := &dataframe.DataFrame{
+		Columns: []string{"k", "r"},
+		Data:    toSeries([][]any{{nil, 2, math.NaN()}, {"x", "y", "z"}}),
+	}
+
+	t.Run("nulls never match", func(t *testing.T) {
+		result, err := left.Merge(right, "k", dataframe.FullMerge)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkFrame(t, result, []string{"k", "l", "r"}, [][]any{
+			{int64(1), nil, int64(2), nil, math.NaN()},
+			{"a", "b", "c", nil, nil},
+			{nil, nil, "y", "x", "z"},
+		})
+	})
+
+	t.Run("match nulls", func(t *testing.T) {
+		result, err := left.MergeWith(right, dataframe.MergeOptions{How: dataframe.InnerMerge, On: []string{"k"}, MatchNulls: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkFrame(t, result, []string{"k", "l", "r"}, [][]any{
+			{nil, nil, int64(2)},
+			{"b", "b", "c"},
+			{"x", "z", "y"},
+		})
+	})
+
+	t.Run("composite keys with nulls", func(t *testing.T) {
+		left := &dataframe.DataFrame{
+			Columns: []string{"a", "b", "l"},
+			Data:    toSeries([][]any{{1, 1}, {nil, "x"}, {"p", "q"}}),
+		}
+		right := &dataframe.DataFrame{
+			Columns: []string{"a", "b", "r"},
+			Data:    toSeries([][]any{{1.0, 1}, {nil, "x"}, {"s", "t"}}),
+		}
+		for _, matchNulls := range []bool{false, true} {
+			result, err := left.MergeWith(right, dataframe.MergeOptions{On: []string{"a", "b"}, MatchNulls: matchNulls})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			want := []any{"t"}
+			if matchNulls {
+				want = []any{"s", "t"}
+			}
+			if got := seriesValues(result.Data[3]); !valuesClose(got, want) {
+				t.Errorf("MatchNulls %v: expected %v, got %v", matchNulls, want, got)
+			}
+		}
+	})
+
+	t.Run("composite keys compare values", func(t *testing.T) {
+		type point struct{ X, Y float64 }
+		negZero := math.Copysign(0, -1)
+		left := &dataframe.DataFrame{
+			Columns: []string{"p", "s", "l"},
+			Data: []dataframe.Series{
+				dataframe.NewObjectCol([]any{point{negZero, 1}, point{2, 3}, point{4, 5}}),
+				dataframe.NewStringCol([]string{"a", "b c", "d"}),
+				dataframe.NewStringCol([]string{"x", "y", "z"}),
+			},
+		}
+		// {0 1} equals {-0 1} under ==, and "b c" must not match the text of other parts
+		right := &dataframe.DataFrame{
+			Columns: []string{"p", "s", "r"},
+			Data: []dataframe.Series{
+				dataframe.NewObjectCol([]any{point{0, 1}, point{2, 3}, "{4 5}"}),
+				dataframe.NewStringCol([]string{"a", "b c", "d"}),
+				dataframe.NewIntCol([]int64{1, 2, 3}),
+			},
+		}
+		result, err := left.MergeWith(right, dataframe.MergeOptions{On: []string{"p", "s"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := seriesValues(result.Data[3]); !valuesClose(got, []any{int64(1), int64(2)}) {
+			t.Errorf("expected the first two rows to match, got %v", got)
+		}
+	})
+
+	t.Run("composite keys with many columns", func(t *testing.T) {
+		columns := []string{"k1", "k2", "k3", "k4", "k5", "k6", "v"}
+		left := &dataframe.DataFrame{
+			Columns: columns,
+			Data:    toSeries([][]any{{1, 1}, {2, 2}, {3, 3}, {4, 4}, {5, 5}, {6, 7}, {"a", "b"}}),
+		}
+		right := &dataframe.DataFrame{
+			Columns: columns,
+			Data:    toSeries([][]any{{1, 1}, {2, 2}, {3, 3}, {4, 4}, {5, 5}, {7, 8}, {"c", "d"}}),
+		}
+		result, err := left.MergeWith(right, dataframe.MergeOptions{On: columns[:6]})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkFrame(t, result, []string{"k1", "k2", "k3", "k4", "k5", "k6", "v_x", "v_y"}, [][]any{
+			{int64(1)}, {int64(2)}, {int64(3)}, {int64(4)}, {int64(5)}, {int64(7)}, {"b"}, {"c"},
+		})
+	})
+}
+
+// TestMergeProperties compares every merge type on random frames against a nested-loop
+// reference implementation.
+//
+// Each case builds two small frames whose composite keys mix ints, integral floats,
+// strings and nulls, merges them and compares the sequence of (left row, right row) pairs
+// with the reference, for both null semantics. The engines take turns, so all of them
+// are held to the reference; every other case sorts the frames by key so that
+// SortMergeEngine can take part.
+func TestMergeProperties(t *testing.T) {
+	rng := rand.New(rand.NewSource(18))
+
+	intKey := func(float bool) any {
+		v := rng.Intn(4)
+		switch {
+		case v == 3:
+			return nil
+		case float:
+			return float64(v)
+		case rng.Intn(2) == 0:
+			return int32(v)
+		default:
+			return v
+		}
+	}
+	strKey := func() any {
+		if v := rng.Intn(3); v < 2 {
+			return string(rune('a' + v))
+		}
+		return nil
+	}
+	// nullsFirst orders two key values as SortMergeEngine expects, with nulls first
+	nullsFirst := func(a, b any) int {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		case b == nil:
+			return 1
+		}
+		c, _ := compareAny(a, b)
+		return c
+	}
+	randomFrame := func(id string, float, sorted bool) *dataframe.DataFrame {
+		rows := make([][2]any, rng.Intn(10))
+		for i := range rows {
+			rows[i] = [2]any{intKey(float), strKey()}
+		}
+		if sorted {
+			slices.SortStableFunc(rows, func(a, b [2]any) int {
+				return cmp.Or(nullsFirst(a[0], b[0]), nullsFirst(a[1], b[1]))
+			})
+		}
+		k1, k2, ids := make([]any, len(rows)), make([]any, len(rows)), make([]any, len(rows))
+		for i, row := range rows {
+			k1[i], k2[i], ids[i] = row[0], row[1], i
+		}
+		return &dataframe.DataFrame{
+			Columns: []string{"k1", "k2", id},
+			Data:    []dataframe.Series{dataframe.NewObjectCol(k1), dataframe.NewObjectCol(k2), dataframe.NewSeries(ids)},
+		}
+	}
+
+	// matches is the reference key comparison: every key column compares equal, and null
+	// parts match only each other and only with matchNulls
+	matches := func(left, right *dataframe.DataFrame, l, r int, keys []string, matchNulls bool) bool {
+		for c := range keys {
+			a, b := left.Data[c].At(l), right.Data[c].At(r)
+			if a == nil || b == nil {
+				if a == nil && b == nil && matchNulls {
+					continue
+				}
+				return false
+			}
+			if c, ok := compareAny(a, b); !ok || c != 0 {
+				return false
+			}
+		}
+		return true
+	}
+	reference := func(left, right *dataframe.DataFrame, how dataframe.MergeHow, keys []string, matchNulls bool) [][2]int {
+		nl, nr := left.Data[0].Len(), right.Data[0].Len()
+		var pairs [][2]int
+		matchedRight := make([]bool, nr)
+		switch how {
+		case dataframe.RightMerge:
+			for r := 0; r < nr; r++ {
+				found := false
+				for l := 0; l < nl; l++ {
+					if matches(left, right, l, r, keys, matchNulls) {
+						pairs = append(pairs, [2]int{l, r})
+						found = true
+					}
+				}
+				if !found {
+					pairs = append(pairs, [2]int{-1, r})
+				}
+			}
+			return pairs
+		}
+		for l := 0; l < nl; l++ {
+			found := false
+			for r := 0; r < nr; r++ {
+				if matches(left, right, l, r, keys, matchNulls) {
+					found = true
+					matchedRight[r] = true
+					switch how {
+					case dataframe.InnerMerge, dataframe.LeftMerge, dataframe.FullMerge:
+						pairs = append(pairs, [2]int{l, r})
+					}
+				}
+			}
+			switch {
+			case how == dataframe.SemiMerge && found:
+				pairs = append(pairs, [2]int{l, -1})
+			case (how == dataframe.AntiMerge || how == dataframe.LeftMerge || how == dataframe.FullMerge) && !found:
+				pairs = append(pairs, [2]int{l, -1})
+			}
+		}
+		if how == dataframe.FullMerge {
+			for r := 0; r < nr; r++ {
+				if !matchedRight[r] {
+					pairs = append(pairs, [2]int{-1, r})
+				}
+			}
+		}
+		return pairs
+	}
+	rowIDs := func(df *dataframe.DataFrame, col string) []int {
+		series, err := df.Col(col)
+		if err != nil {
+			return nil
+		}
+		ids := make([]int, series.Len())
+		for i := range ids {
+			ids[i] = -1
+			if v, ok := series.At(i).(int64); ok {
+				ids[i] = int(v)
+			}
+		}
+		return ids
+	}
+
+	hows := []dataframe.MergeHow{
+		dataframe.InnerMerge, dataframe.LeftMerge, dataframe.RightMerge,
+		dataframe.FullMerge, dataframe.SemiMerge, dataframe.AntiMerge,
+	}
+	engines := []dataframe.MergeEngine{dataframe.HashEngine, dataframe.ParallelHashEngine, dataframe.SortMergeEngine}
+	for iteration := 0; iteration < 300; iteration++ {
+		sorted := iteration%2 == 0
+		left, right := randomFrame("lid", false, sorted), randomFrame("rid", true, sorted)
+		keys := []string{"k1", "k2"}[:1+rng.Intn(2)]
+		for _, how := range hows {
+			for _, matchNulls := range []bool{false, true} {
+				engine := engines[(iteration+len(how))%len(engines)]
+				if engine == dataframe.SortMergeEngine && !sorted {
+					engine = dataframe.HashEngine
+				}
+				result, err := left.MergeWith(right, dataframe.MergeOptions{How: how, On: keys, MatchNulls: matchNulls, Engine: engine})
+				if err != nil {
+					t.Fatalf("iteration %d, %s: unexpected error: %v", iteration, how, err)
+				}
+				want := reference(left, right, how, keys, matchNulls)
+				lids := rowIDs(result, "lid")
+				rids := rowIDs(result, "rid")
+				if len(lids) != len(want) {
+					t.Fatalf("iteration %d, %s (match nulls %v, keys %v): expected %d rows, got %d\nleft:\n%v\nright:\n%v",
+						iteration, how, matchNulls, keys, len(want), len(lids), left, right)
+				}
+				for i, pair := range want {
+					rid := -1
+					if rids != nil {
+						rid = rids[i]
+					}
+					// semi and anti merges leave out the right columns
+					if how == dataframe.SemiMerge || how == dataframe.AntiMerge {
+						pair[1] = -1
+					}
+					if lids[i] != pair[0] || rid != pair[1] {
+						t.Fatalf("iteration %d, %s (match nulls %v, keys %v): row %d is (%d, %d), expected (%d, %d)",
+							iteration, how, matchNulls, keys, i, lids[i], rid, pair[0], pair[1])
+					}
+				}
+			}
+		}
+	}
+
+	t.Run("mixed width key dtype", func(t *testing.T) {
+		left := &dataframe.DataFrame{
+			Columns: []string{"k", "lid"},
+			Data:    toSeries([][]any{{int64(1), int64(2), int64(3)}, {0, 1, 2}}),
+		}
+		for _, tt := range []struct {
+			name  string
+			right []any
+			dtype dataframe.DType
+			keys  []any
+		}{
+			{name: "integral", right: []any{2.0, 3.0, 4.0}, dtype: dataframe.IntType, keys: []any{int64(1), int64(2), int64(3), int64(4)}},
+			{name: "fractional", right: []any{2.0, 4.5}, dtype: dataframe.FloatType, keys: []any{1.0, 2.0, 3.0, 4.5}},
+		} {
+			right := &dataframe.DataFrame{Columns: []string{"k"}, Data: toSeries([][]any{tt.right})}
+			for _, engine := range engines {
+				inner, err := left.MergeWith(right, dataframe.MergeOptions{How: dataframe.InnerMerge, On: []string{"k"}, Engine: engine})
+				if err != nil {
+					t.Fatalf("%s, %s: unexpected error: %v", tt.name, engine, err)
+				}
+				if inner.Data[0].DType() != dataframe.IntType {
+					t.Errorf("%s, %s: expected an inner merge key of %s, got %s", tt.name, engine, dataframe.IntType, inner.Data[0].DType())
+				}
+				full, err := left.MergeWith(right, dataframe.MergeOptions{How: dataframe.FullMerge, On: []string{"k"}, Engine: engine})
+				if err != nil {
+					t.Fatalf("%s, %s: unexpected error: %v", tt.name, engine, err)
+				}
+				if full.Data[0].DType() != tt.dtype {
+					t.Errorf("%s, %s: expected a full merge key of %s, got %s", tt.name, engine, tt.dtype, full.Data[0].DType())
+				}
+				if got := seriesValues(full.Data[0]); !valuesClose(got, tt.keys) {
+					t.Errorf("%s, %s: expected keys %v, got %v", tt.name, engine, tt.keys, got)
+				}
+			}
+		}
+	})
+}
+
+// TestMergeEngines checks that the parallel hash join returns exactly the rows of the
+// sequential one, in the same order, on frames large enough to spread over every
+// partition. Besides int and string keys, the frames hold object keys mixing structs
+// whose float fields are 0.0 or -0.0, which are equal, with strings.
+func TestMergeEngines(t *testing.T) {
+	type point struct {
+		X int64
+		Y float64
+	}
+	rng := rand.New(rand.NewSource(19))
+	randomFrame := func(rows int, id string) *dataframe.DataFrame {
+		keys, names, ids := make([]int64, rows), make([]string, rows), make([]int64, rows)
+		objects := make([]any, rows)
+		for i := range keys {
+			keys[i], names[i], ids[i] = rng.Int63n(100_000), string(rune('a'+rng.Intn(3))), int64(i)
+			switch x := rng.Int63n(50_000); rng.Intn(3) {
+			case 0:
+				objects[i] = point{x, 0}
+			case 1:
+				objects[i] = point{x, math.Copysign(0, -1)}
+			default:
+				objects[i] = strconv.FormatInt(x, 10)
+			}
+		}
+		key := dataframe.NewIntCol(keys)
+		for i := 0; i < rows; i += 97 {
+			key.SetNull(i)
+		}
+		return &dataframe.DataFrame{
+			Columns: []string{"key", "name", "object", id},
+			Data: []dataframe.Series{
+				key, dataframe.NewStringCol(names), dataframe.NewObjectCol(objects), dataframe.NewIntCol(ids),
+			},
+		}
+	}
+	left, right := randomFrame(120_000, "lid"), randomFrame(80_000, "rid")
+
+	hows := []dataframe.MergeHow{
+		dataframe.InnerMerge, dataframe.LeftMerge, dataframe.RightMerge,
+		dataframe.FullMerge, dataframe.SemiMerge, dataframe.AntiMerge,
+	}
+	for _, how := range hows {
+		for _, keys := range [][]string{{"key"}, {"key", "name"}, {"object"}, {"object", "name"}} {
+			want, err := left.MergeWith(right, dataframe.MergeOptions{How: how, On: keys, Engine: dataframe.HashEngine})
+			if err != nil {
+				t.Fatalf("%s on %v: unexpected error: %v", how, keys, err)
+			}
+			got, err := left.MergeWith(right, dataframe.MergeOptions{How: how, On: keys, Engine: dataframe.ParallelHashEngine})
+			if err != nil {
+				t.Fatalf("%s on %v: unexpected error: %v", how, keys, err)
+			}
+			if !slices.Equal(got.Columns, want.Columns) {
+				t.Fatalf("%s on %v: expected columns %v, got %v", how, keys, want.Columns, got.Columns)
+			}
+			for c, name := range want.Columns {
+				if got.Data[c].Len() != want.Data[c].Len() {
+					t.Fatalf("%s on %v: expected %d rows, got %d", how, keys, want.Data[c].Len(), got.Data[c].Len())
+				}
+				for i := 0; i < want.Data[c].Len(); i++ {
+					if got.Data[c].At(i) != want.Data[c].At(i) {
+						t.Fatalf("%s on %v: column %s row %d is %v, expected %v", how, keys, name, i, got.Data[c].At(i), want.Data[c].At(i))
+					}
+				}
+			}
+		}
+	}
+
+	if _, err := left.MergeWith(right, dataframe.MergeOptions{On: []string{"key"}, Engine: "bogus"}); err == nil {
+		t.Error("expected an error for an invalid merge engine")
+	}
+}
+
+// TestMergeSortEngine tests the errors of SortMergeEngine; its results are checked by
+// TestMergeProperties.
+func TestMergeSortEngine(t *testing.T) {
+	sortMerge := dataframe.MergeOptions{On: []string{"customer"}, Engine: dataframe.SortMergeEngine}
+
+	t.Run("unsorted", func(t *testing.T) {
+		left := &dataframe.DataFrame{
+			Columns: []string{"customer"},
+			Data:    []dataframe.Series{dataframe.NewStringCol([]string{"b", "a"})},
+		}
+		right := &dataframe.DataFrame{
+			Columns: []string{"customer"},
+			Data:    []dataframe.Series{dataframe.NewStringCol([]string{"a", "b"})},
+		}
+		_, err := left.MergeWith(right, sortMerge)
+		if err == nil || !strings.Contains(err.Error(), "left DataFrame is not sorted") {
+			t.Errorf("expected an error for an unsorted left DataFrame, got %v", err)
+		}
+		sortMerge.How = dataframe.RightMerge
+		_, err = right.MergeWith(left, sortMerge)
+		if err == nil || !strings.Contains(err.Error(), "right DataFrame is not sorted") {
+			t.Errorf("expected an error for an unsorted right DataFrame, got %v", err)
+		}
+	})
+
+	t.Run("incomparable keys", func(t *testing.T) {
+		left := &dataframe.DataFrame{
+			Columns: []string{"customer"},
+			Data:    []dataframe.Series{dataframe.NewStringCol([]string{"a"})},
+		}
+		right := &dataframe.DataFrame{
+			Columns: []string{"customer"},
+			Data:    []dataframe.Series{dataframe.NewIntCol([]int64{1})},
+		}
+		_, err := left.MergeWith(right, sortMerge)
+		if err == nil || !strings.Contains(err.Error(), "cannot compare") {
+			t.Errorf("expected an error for incomparable keys, got %v", err)
+		}
+	})
+}
+
+// TestMergeAsof tests nearest-key merges.
+//
+// The test suite covers:
+//   - Backward, forward and nearest matches, exact matches and ties
+//   - Exact matching on by columns
+//   - Numeric and duration tolerances
+//   - Null keys on either side
+//   - Errors for an unsorted right DataFrame and invalid arguments
+func TestMergeAsof(t *testing.T) {
+	newTrades := func() *dataframe.DataFrame {
+		at := dataframe.NewIntCol([]int64{1, 5, 10, 3, 8})
+		at.SetNull(4)
+		return &dataframe.DataFrame{
+			Columns: []string{"at", "ticker", "qty"},
+			Data: []dataframe.Series{
+				at,
+				dataframe.NewStringCol([]string{"MSFT", "MSFT", "GOOG", "GOOG", "MSFT"}),
+				dataframe.NewIntCol([]int64{100, 50, 20, 70, 10}),
+			},
+		}
+	}
+	newQuotes := func() *dataframe.DataFrame {
+		at := dataframe.NewFloatCol([]float64{0, 2, 4, 4, 7, 9, 12})
+		at.SetNull(5)
+		return &dataframe.DataFrame{
+			Columns: []string{"at", "ticker", "bid"},
+			Data: []dataframe.Series{
+				at,
+				dataframe.NewStringCol([]string{"MSFT", "GOOG", "MSFT", "GOOG", "MSFT", "GOOG", "GOOG"}),
+				dataframe.NewFloatCol([]float64{1.0, 2.0, 3.0, 4.0, 5.0, 6.0, 7.0}),
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		by        []string
+		direction dataframe.AsofDirection
+		tolerance any
+		columns   []string
+		values    [][]any
+	}{
+		{
+			name:    "backward",
+			columns: []string{"at", "ticker_x", "qty", "ticker_y", "bid"},
+			values: [][]any{
+				{int64(1), int64(5), int64(10), int64(3), nil},
+				{"MSFT", "MSFT", "GOOG", "GOOG", "MSFT"},
+				{int64(100), int64(50), int64(20), int64(70), int64(10)},
+				{"MSFT", "GOOG", "MSFT", "GOOG", nil},
+				{1.0, 4.0, 5.0, 2.0, nil},
+			},
+		},
+		{
+			name:      "forward by ticker",
+			by:        []string{"ticker"},
+			direction: dataframe.AsofForward,
+			columns:   []string{"at", "ticker", "qty", "bid"},
+			values: [][]any{
+				{int64(1), int64(5), int64(10), int64(3), nil},
+				{"MSFT", "MSFT", "GOOG", "GOOG", "MSFT"},
+				{int64(100), int64(50), int64(20), int64(70), int64(10)},
+				{3.0, 5.0, 7.0, 4.0, nil},
+			},
+		},
+		{
+			name:      "nearest by ticker",
+			by:        []string{"ticker"},
+			direction: dataframe.AsofNearest,
+			columns:   []string{"at", "ticker", "qty", "bid"},
+			values: [][]any{
+				{int64(1), int64(5), int64(10), int64(3), nil},
+				{"MSFT", "MSFT", "GOOG", "GOOG", "MSFT"},
+				{int64(100), int64(50), int64(20), int64(70), int64(10)},
+				{1.0, 3.0, 7.0, 2.0, nil},
+			},
+		},
+		{
+			name:      "backward with tolerance",
+			by:        []string{"ticker"},
+			tolerance: 1,
+			columns:   []string{"at", "ticker", "qty", "bid"},
+			values: [][]any{
+				{int64(1), int64(5), int64(10), int64(3), nil},
+				{"MSFT", "MSFT", "GOOG", "GOOG", "MSFT"},
+				{int64(100), int64(50), int64(20), int64(70), int64(10)},
+				{1.0, 3.0, nil, 2.0, nil},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := newTrades().MergeAsof(newQuotes(), "at", tt.by, tt.direction, tt.tolerance)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			checkFrame(t, result, tt.columns, tt.values)
+		})
+	}
+
+	t.Run("duration tolerance", func(t *testing.T) {
+		base := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+		trades := &dataframe.DataFrame{
+			Columns: []string{"time"},
+			Data:    []dataframe.Series{dataframe.NewDateTimeCol([]time.Time{base.Add(time.Second), base.Add(5 * time.Second)})},
+		}
+		quotes := &dataframe.DataFrame{
+			Columns: []string{"time", "bid"},
+			Data: []dataframe.Series{
+				dataframe.NewDateTimeCol([]time.Time{base, base.Add(2 * time.Second)}),
+				dataframe.NewFloatCol([]float64{51.95, 51.97}),
+			},
+		}
+		result, err := trades.MergeAsof(quotes, "time", nil, dataframe.AsofBackward, 2*time.Second)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkFrame(t, result, []string{"time", "bid"}, [][]any{
+			{base.Add(time.Second), base.Add(5 * time.Second)},
+			{51.95, nil},
+		})
+	})
+
+	errorTests := []struct {
+		name      string
+		right     func() *dataframe.DataFrame
+		on        string
+		direction dataframe.AsofDirection
+		tolerance any
+		want      string
+	}{
+		{
+			name: "unsorted right",
+			right: func() *dataframe.DataFrame {
+				quotes := newQuotes()
+				quotes.Data[0] = dataframe.NewFloatCol([]float64{0, 2, 4, 3, 7, 9, 12})
+				return quotes
+			},
+			on:   "at",
+			want: "not sorted by 'at'",
+		},
+		{name: "missing column", right: newQuotes, on: "time", want: "not found"},
+		{name: "invalid direction", right: newQuotes, on: "at", direction: "sideways", want: "invalid asof direction"},
+		{name: "negative tolerance", right: newQuotes, on: "at", tolerance: -1, want: "must not be negative"},
+		{name: "invalid tolerance", right: newQuotes, on: "at", tolerance: "1s", want: "invalid asof tolerance"},
+		{name: "duration on numbers", right: newQuotes, on: "at", tolerance: time.Second, want: "tolerance does not fit"},
+		{name: "nearest on strings", right: newQuotes, on: "ticker", direction: dataframe.AsofNearest, want: "cannot measure the distance"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			right := tt.right()
+			if tt.on == "ticker" {
+				right.Data[1] = dataframe.NewStringCol([]string{"A", "B", "C", "D", "E", "F", "G"})
+			}
+			_, err := newTrades().MergeAsof(right, tt.on, nil, tt.direction, tt.tolerance)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// compareAny compares two non-null scalar values, ints and floats numerically.
+func compareAny(a, b any) (int, bool) {
+	toFloat := func(v any) (float64, bool) {
+		switch x := v.(type) {
+		case int:
+			return float64(x), true
+		case int32:
+			return float64(x), true
+		case int64:
+			return float64(x), true
+		case float64:
+			return x, true
+		}
+		return 0, false
+	}
+	if x, ok := toFloat(a); ok {
+		if y, ok := toFloat(b); ok {
+			return cmp.Compare(x, y), true
+		}
+		return 0, false
+	}
+	if x, ok := a.(string); ok {
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,148 @@
+package dataframe_test
+
+import (
+	"bytes"
+	"gpandas/dataframe"
+	"testing"
+)
+
+// TestSeriesNulls tests the validity bitmap of typed columns.
+//
+// The test covers:
+//   - Columns without nulls reporting no nulls
+//   - SetNull across more than one 64-row bitmap word
+//   - At returning nil and Value returning the zero value for nulls
+//   - Take and Copy carrying nulls over
+func TestSeriesNulls(t *testing.T) {
+	values := make([]int64, 130)
+	for i := range values {
+		values[i] = int64(i)
+	}
+	col := dataframe.NewIntCol(values)
+	if col.NullCount() != 0 {
+		t.Errorf("expected no nulls, got %d", col.NullCount())
+	}
+
+	for _, i := range []int{0, 63, 64, 129} {
+		col.SetNull(i)
+	}
+	if col.NullCount() != 4 {
+		t.Errorf("expected 4 nulls, got %d", col.NullCount())
+	}
+	if !col.IsNull(64) || col.IsNull(65) {
+		t.Errorf("unexpected null flags at 64/65: %v/%v", col.IsNull(64), col.IsNull(65))
+	}
+	if col.At(63) != nil || col.Value(63) != 0 {
+		t.Errorf("expected nil and zero value for null, got %v and %v", col.At(63), col.Value(63))
+	}
+
+	taken := col.Take([]int{1, 64, -1})
+	if taken.IsNull(0) || !taken.IsNull(1) || !taken.IsNull(2) {
+		t.Errorf("unexpected null flags after Take: %v", seriesValues(taken))
+	}
+	if taken.DType() != dataframe.IntType {
+		t.Errorf("expected Take with missing rows to keep dtype int64, got %s", taken.DType())
+	}
+
+	copied := col.Copy().(*dataframe.IntCol)
+	copied.SetNull(1)
+	if col.IsNull(1) {
+		t.Error("expected Copy to have an independent validity bitmap")
+	}
+}
+
+// TestNullMasks tests IsNull and NotNull at Series and DataFrame level.
+func TestNullMasks(t *testing.T) {
+	df := &dataframe.DataFrame{
+		Columns: []string{"A", "B"},
+		Data:    toSeries([][]any{{1, nil, 3}, {"x", "y", nil}}),
+	}
+
+	mask, err := df.IsNull()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := toSeries([][]any{{false, true, false}, {false, false, true}})
+	for i := range expected {
+		if !seriesEqual(mask.Data[i], expected[i]) {
+			t.Errorf("IsNull column %d mismatch: expected %v, got %v", i, seriesValues(expected[i]), seriesValues(mask.Data[i]))
+		}
+	}
+
+	notNull, err := df.NotNull()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !seriesEqual(notNull.Data[0], dataframe.NewBoolCol([]bool{true, false, true})) {
+		t.Errorf("NotNull mismatch: got %v", seriesValues(notNull.Data[0]))
+	}
+
+	var nilDF *dataframe.DataFrame
+	if _, err := nilDF.IsNull(); err == nil {
+		t.Error("expected error for nil DataFrame")
+	}
+}
+
+// TestNullPropagation tests that nulls flow through Merge, String and CSV output.
+func TestNullPropagation(t *testing.T) {
+	left := &dataframe.DataFrame{
+		Columns: []string{"ID", "Score"},
+		Data:    toSeries([][]any{{1, 2, 3}, {1.5, nil, 3.5}}),
+	}
+	right := &dataframe.DataFrame{
+		Columns: []string{"ID", "Name"},
+		Data:    toSeries([][]any{{1, 2}, {"Alice", "Bob"}}),
+	}
+
+	merged, err := left.Merge(right, "ID", dataframe.LeftMerge)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("merge keeps typed columns", func(t *testing.T) {
+		if merged.Data[1].DType() != dataframe.FloatType || merged.Data[2].DType() != dataframe.StringType {
+			t.Errorf("unexpected dtypes: %s, %s", merged.Data[1].DType(), merged.Data[2].DType())
+		}
+		if !merged.Data[1].IsNull(1) || !merged.Data[2].IsNull(2) {
+			t.Errorf("expected nulls to propagate, got %v and %v", seriesValues(merged.Data[1]), seriesValues(merged.Data[2]))
+		}
+	})
+
+	t.Run("string rendering", func(t *testing.T) {
+		expected := `+----+-------+--------+
+| ID | Score | Name   |
++----+-------+--------+
+| 1  | 1.5   | Alice  |
+| 2  | NaN   | Bob    |
+| 3  | 3.5   | <null> |
++----+-------+--------+
+[3 rows x 3 columns]
+`
+		if got := merged.String(); got != expected {
+			t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+		}
+	})
+
+	t.Run("csv default null token", func(t *testing.T) {
+		got, err := merged.ToCSV("")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := "ID,Score,Name\n1,1.5,Alice\n2,,Bob\n3,3.5,\n"
+		if got != expected {
+			t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+		}
+	})
+
+	t.Run("csv custom null token", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := merged.WriteCSV(&buf, dataframe.ToCSVOptions{Separator: ";", NullRep: "NA"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := "ID;Score;Name\n1;1.5;Alice\n2;NA;Bob\n3;3.5;NA\n"
+		if buf.String() != expected {
+			t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+		}
+	})
+}
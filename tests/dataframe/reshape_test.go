@@ -0,0 +1,246 @@
+package dataframe_test
+
+import (
+	"gpandas/dataframe"
+	"strings"
+	"testing"
+)
+
+// newSalesFrame returns a long frame of sales by region, quarter and product, with a null
+// region and a null quarter.
+func newSalesFrame() *dataframe.DataFrame {
+	return &dataframe.DataFrame{
+		Columns: []string{"region", "quarter", "product", "sales", "units"},
+		Data: toSeries([][]any{
+			{"East", "East", "East", "West", nil, "West"},
+			{"Q1", "Q1", "Q2", "Q2", "Q1", nil},
+			{"A", "B", "A", "A", "A", "B"},
+			{100, 20, 80, 95, 5, 7},
+			{1, 2, 3, 4, 5, 6},
+		}),
+	}
+}
+
+// newTempsFrame returns a long frame of temperatures by date and city.
+func newTempsFrame() *dataframe.DataFrame {
+	return &dataframe.DataFrame{
+		Columns: []string{"date", "city", "temp"},
+		Data: toSeries([][]any{
+			{"d2", "d1", "d1"},
+			{"Berlin", "Paris", "Berlin"},
+			{4.0, 7.0, 3.5},
+		}),
+	}
+}
+
+// TestPivotTable tests PivotTable.
+//
+// The test suite covers:
+//   - Summing with a fill value and margins aggregating the original values
+//   - Margins turning a non-string first index column into an object column
+//   - The default mean aggregation leaving empty cells null
+//   - Default value columns, and names prefixed by the value column
+//   - Several columns keys joined into one name
+//   - Rows with null keys left out
+//   - Invalid arguments and failing aggregations
+func TestPivotTable(t *testing.T) {
+	tests := []struct {
+		name     string
+		index    []string
+		columns  []string
+		values   []string
+		aggfunc  dataframe.AggFunc
+		fill     any
+		margins  bool
+		wantCols []string
+		want     [][]any
+	}{
+		{
+			name:     "sum with margins",
+			index:    []string{"region"},
+			columns:  []string{"quarter"},
+			values:   []string{"sales"},
+			aggfunc:  dataframe.AggSum,
+			fill:     0,
+			margins:  true,
+			wantCols: []string{"region", "Q1", "Q2", "All"},
+			want: [][]any{
+				{"East", "West", "All"},
+				{int64(120), int64(0), int64(120)},
+				{int64(80), int64(95), int64(175)},
+				{int64(200), int64(95), int64(295)},
+			},
+		},
+		{
+			name:     "mean margins",
+			index:    []string{"region"},
+			columns:  []string{"quarter"},
+			values:   []string{"sales"},
+			margins:  true,
+			wantCols: []string{"region", "Q1", "Q2", "All"},
+			want: [][]any{
+				{"East", "West", "All"},
+				{60.0, nil, 60.0},
+				{80.0, 95.0, 87.5},
+				{200.0 / 3, 95.0, 73.75},
+			},
+		},
+		{
+			name:     "default values",
+			index:    []string{"region"},
+			columns:  []string{"quarter"},
+			aggfunc:  dataframe.AggSum,
+			wantCols: []string{"region", "sales_Q1", "sales_Q2", "units_Q1", "units_Q2"},
+			want: [][]any{
+				{"East", "West"},
+				{int64(120), nil},
+				{int64(80), int64(95)},
+				{int64(3), nil},
+				{int64(3), int64(4)},
+			},
+		},
+		{
+			name:     "several columns keys",
+			index:    []string{"region"},
+			columns:  []string{"quarter", "product"},
+			values:   []string{"sales"},
+			aggfunc:  dataframe.AggMax,
+			fill:     -1.5,
+			wantCols: []string{"region", "Q1_A", "Q1_B", "Q2_A"},
+			want: [][]any{
+				{"East", "West"},
+				{100.0, -1.5},
+				{20.0, -1.5},
+				{int64(80), int64(95)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := newSalesFrame().PivotTable(tt.index, tt.columns, tt.values, tt.aggfunc, tt.fill, tt.margins)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			checkFrame(t, table, tt.wantCols, tt.want)
+		})
+	}
+
+	t.Run("margins with an int index", func(t *testing.T) {
+		table, err := newSalesFrame().PivotTable([]string{"units"}, []string{"quarter"}, []string{"sales"}, dataframe.AggSum, nil, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if dtype := table.Data[0].DType(); dtype != dataframe.ObjectType {
+			t.Errorf("expected the labelled index column to become %s, got %s", dataframe.ObjectType, dtype)
+		}
+		if got := seriesValues(table.Data[0]); !valuesClose(got, []any{int64(1), int64(2), int64(3), int64(4), int64(5), "All"}) {
+			t.Errorf("expected the index values followed by All, got %v", got)
+		}
+	})
+
+	errorTests := []struct {
+		name    string
+		index   []string
+		values  []string
+		aggfunc dataframe.AggFunc
+		want    string
+	}{
+		{name: "no index", aggfunc: dataframe.AggSum, want: "at least one index"},
+		{name: "missing column", index: []string{"country"}, aggfunc: dataframe.AggSum, want: "country"},
+		{name: "value is a key", index: []string{"region"}, values: []string{"quarter"}, aggfunc: dataframe.AggCount, want: "more than once"},
+		{name: "numeric aggregation", index: []string{"region"}, values: []string{"product"}, aggfunc: dataframe.AggSum, want: "cannot compute sum"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newSalesFrame().PivotTable(tt.index, []string{"quarter"}, tt.values, tt.aggfunc, nil, false)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestPivotMelt tests Pivot and Melt.
+//
+// The test suite covers:
+//   - Pivoting without aggregation, with missing cells left null
+//   - Duplicate index and columns pairs rejected
+//   - Melting back to long form, keeping the dtype of the value columns, with a fresh index
+//   - Default names and name collisions
+//   - Keys that cannot be hashed rejected instead of panicking
+func TestPivotMelt(t *testing.T) {
+	wide, err := newTempsFrame().Pivot("date", "city", "temp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkFrame(t, wide, []string{"date", "Berlin", "Paris"}, [][]any{
+		{"d1", "d2"},
+		{3.5, 4.0},
+		{7.0, nil},
+	})
+
+	t.Run("duplicates", func(t *testing.T) {
+		_, err := newSalesFrame().Pivot("region", "quarter", "sales")
+		if err == nil || !strings.Contains(err.Error(), "rows 0 and 1 share index East and column Q1") {
+			t.Errorf("expected an error for duplicate entries, got %v", err)
+		}
+	})
+
+	t.Run("melt", func(t *testing.T) {
+		long, err := wide.Melt([]string{"date"}, nil, "city", "temp")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkFrame(t, long, []string{"date", "city", "temp"}, [][]any{
+			{"d1", "d2", "d1", "d2"},
+			{"Berlin", "Berlin", "Paris", "Paris"},
+			{3.5, 4.0, 7.0, nil},
+		})
+	})
+
+	t.Run("melt columns", func(t *testing.T) {
+		long, err := newSalesFrame().Melt([]string{"region"}, []string{"units", "sales"}, "", "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := long.Data[2].DType(); got != dataframe.IntType {
+			t.Errorf("expected int values, got %s", got)
+		}
+		checkFrame(t, long, []string{"region", "variable", "value"}, [][]any{
+			{"East", "East", "East", "West", nil, "West", "East", "East", "East", "West", nil, "West"},
+			{"units", "units", "units", "units", "units", "units", "sales", "sales", "sales", "sales", "sales", "sales"},
+			{int64(1), int64(2), int64(3), int64(4), int64(5), int64(6), int64(100), int64(20), int64(80), int64(95), int64(5), int64(7)},
+		})
+
+		long, err = newTempsFrame().Melt(nil, []string{"temp"}, "", "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := indexLabels(long); !valuesClose(got, []any{int64(0), int64(1), int64(2)}) {
+			t.Errorf("expected a fresh index, got %v", got)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := newTempsFrame().Pivot("date", "city", "rain"); err == nil {
+			t.Error("expected an error for a missing column")
+		}
+		tags := &dataframe.DataFrame{
+			Columns: []string{"tags", "k", "v"},
+			Data: []dataframe.Series{
+				dataframe.NewObjectCol([]any{[]int{1}, "a"}),
+				dataframe.NewStringCol([]string{"x", "y"}),
+				dataframe.NewIntCol([]int64{1, 2}),
+			},
+		}
+		if _, err := tags.Pivot("tags", "k", "v"); err == nil || !strings.Contains(err.Error(), "key 'tags'") {
+			t.Errorf("expected an unhashable key error, got %v", err)
+		}
+		if _, err := newTempsFrame().Melt([]string{"date"}, nil, "date", ""); err == nil || !strings.Contains(err.Error(), "more than once") {
+			t.Errorf("expected an error for a colliding name, got %v", err)
+		}
+		if _, err := newTempsFrame().Melt([]string{"date"}, []string{"date"}, "", ""); err == nil {
+			t.Error("expected an error for a column both id and value")
+		}
+	})
+}
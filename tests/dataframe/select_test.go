@@ -0,0 +1,168 @@
+package dataframe_test
+
+import (
+	"gpandas/dataframe"
+	"testing"
+)
+
+// newSelectFrame returns the frame shared by the column selection tests.
+func newSelectFrame() *dataframe.DataFrame {
+	return &dataframe.DataFrame{
+		Columns: []string{"id", "sales_2023", "sales_2024", "region"},
+		Data:    toSeries([][]any{{1, 2}, {10.5, 20.5}, {11.5, 21.5}, {"north", "south"}}),
+	}
+}
+
+// TestDataFrameSelect tests Select, Drop, Reorder, SelectRegex and SelectPrefix.
+//
+// The test suite covers:
+//   - The resulting column order of each operation
+//   - Validation of unknown, repeated and missing column names
+//   - Invalid regular expressions and patterns that match nothing
+func TestDataFrameSelect(t *testing.T) {
+	tests := []struct {
+		name        string
+		op          func(df *dataframe.DataFrame) (*dataframe.DataFrame, error)
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:     "select in given order",
+			op:       func(df *dataframe.DataFrame) (*dataframe.DataFrame, error) { return df.Select("region", "id") },
+			expected: []string{"region", "id"},
+		},
+		{
+			name:        "select unknown column",
+			op:          func(df *dataframe.DataFrame) (*dataframe.DataFrame, error) { return df.Select("id", "missing") },
+			expectError: true,
+		},
+		{
+			name:        "select repeated column",
+			op:          func(df *dataframe.DataFrame) (*dataframe.DataFrame, error) { return df.Select("id", "id") },
+			expectError: true,
+		},
+		{
+			name:        "select nothing",
+			op:          func(df *dataframe.DataFrame) (*dataframe.DataFrame, error) { return df.Select() },
+			expectError: true,
+		},
+		{
+			name:     "drop keeps order",
+			op:       func(df *dataframe.DataFrame) (*dataframe.DataFrame, error) { return df.Drop("sales_2023", "id") },
+			expected: []string{"sales_2024", "region"},
+		},
+		{
+			name:        "drop unknown column",
+			op:          func(df *dataframe.DataFrame) (*dataframe.DataFrame, error) { return df.Drop("missing") },
+			expectError: true,
+		},
+		{
+			name:     "reorder moves columns to the front",
+			op:       func(df *dataframe.DataFrame) (*dataframe.DataFrame, error) { return df.Reorder("region", "sales_2024") },
+			expected: []string{"region", "sales_2024", "id", "sales_2023"},
+		},
+		{
+			name:        "reorder repeated column",
+			op:          func(df *dataframe.DataFrame) (*dataframe.DataFrame, error) { return df.Reorder("region", "region") },
+			expectError: true,
+		},
+		{
+			name:     "regex",
+			op:       func(df *dataframe.DataFrame) (*dataframe.DataFrame, error) { return df.SelectRegex(`^sales_\d{4}$`) },
+			expected: []string{"sales_2023", "sales_2024"},
+		},
+		{
+			name:     "regex matching nothing",
+			op:       func(df *dataframe.DataFrame) (*dataframe.DataFrame, error) { return df.SelectRegex(`^total`) },
+			expected: []string{},
+		},
+		{
+			name:        "invalid regex",
+			op:          func(df *dataframe.DataFrame) (*dataframe.DataFrame, error) { return df.SelectRegex(`(`) },
+			expectError: true,
+		},
+		{
+			name:     "prefix",
+			op:       func(df *dataframe.DataFrame) (*dataframe.DataFrame, error) { return df.SelectPrefix("re") },
+			expected: []string{"region"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			df := newSelectFrame()
+			result, err := test.op(df)
+			if test.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strSliceEqual(result.Columns, test.expected) {
+				t.Errorf("expected columns %v, got %v", test.expected, result.Columns)
+			}
+			// Every selected column must carry the data of the original column
+			for i, name := range result.Columns {
+				original, _ := df.Col(name)
+				if !seriesEqual(result.Data[i], original) {
+					t.Errorf("column %s: expected %v, got %v", name, seriesValues(original), seriesValues(result.Data[i]))
+				}
+			}
+			if len(df.Columns) != 4 {
+				t.Errorf("expected original DataFrame to keep 4 columns, got %v", df.Columns)
+			}
+		})
+	}
+
+	t.Run("result is independent of the original", func(t *testing.T) {
+		df := newSelectFrame()
+		selected, err := df.Select("id")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		selected.Data[0].(*dataframe.IntCol).SetNull(0)
+		if df.Data[0].IsNull(0) {
+			t.Error("expected modifying the selection to leave the original unchanged")
+		}
+	})
+
+	t.Run("nil dataframe", func(t *testing.T) {
+		var df *dataframe.DataFrame
+		if _, err := df.Select("id"); err == nil {
+			t.Error("expected error but got none")
+		}
+	})
+}
+
+// TestDataFrameCol tests Col and the typed accessor ColAs.
+func TestDataFrameCol(t *testing.T) {
+	df := newSelectFrame()
+
+	col, err := df.Col("region")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if col.DType() != dataframe.StringType || col.At(1) != "south" {
+		t.Errorf("unexpected column: %s %v", col.DType(), seriesValues(col))
+	}
+	if _, err := df.Col("missing"); err == nil {
+		t.Error("expected error for unknown column")
+	}
+
+	sales, err := dataframe.ColAs[float64](df, "sales_2024")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := sales.Values(); len(got) != 2 || got[0] != 11.5 || got[1] != 21.5 {
+		t.Errorf("unexpected values: %v", got)
+	}
+	if _, err := dataframe.ColAs[string](df, "id"); err == nil {
+		t.Error("expected error for dtype mismatch")
+	}
+	if _, err := dataframe.ColAs[int64](df, "missing"); err == nil {
+		t.Error("expected error for unknown column")
+	}
+}
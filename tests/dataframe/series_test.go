@@ -0,0 +1,120 @@
+package dataframe_test
+
+import (
+	"gpandas/dataframe"
+	"math"
+	"testing"
+)
+
+// TestNewSeries tests that NewSeries picks the narrowest typed column for boxed values.
+//
+// The test covers:
+//   - Homogeneous ints, floats, strings and bools mapping to IntCol, FloatCol, StringCol and BoolCol
+//   - Integer widths and []byte being normalized before inference
+//   - nil values becoming nulls without affecting the chosen type
+//   - Mixed and all-nil values falling back to ObjectCol
+//   - Unsigned integers above math.MaxInt64 kept exact in an ObjectCol
+func TestNewSeries(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []any
+		expected dataframe.DType
+	}{
+		{name: "ints", values: []any{1, 2, 3}, expected: dataframe.IntType},
+		{name: "mixed int widths", values: []any{int8(1), int32(2), int64(3), uint16(4)}, expected: dataframe.IntType},
+		{name: "floats", values: []any{1.5, float32(2.5)}, expected: dataframe.FloatType},
+		{name: "strings", values: []any{"a", []byte("b")}, expected: dataframe.StringType},
+		{name: "bools", values: []any{true, false}, expected: dataframe.BoolType},
+		{name: "mixed types", values: []any{1, "a", true}, expected: dataframe.ObjectType},
+		{name: "nil value is null", values: []any{1, nil}, expected: dataframe.IntType},
+		{name: "all nil", values: []any{nil, nil}, expected: dataframe.ObjectType},
+		{name: "empty", values: []any{}, expected: dataframe.ObjectType},
+		{name: "uint64 within int64", values: []any{uint64(math.MaxInt64), uint(1)}, expected: dataframe.IntType},
+		{name: "uint64 overflow", values: []any{uint64(1), uint64(math.MaxUint64)}, expected: dataframe.ObjectType},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := dataframe.NewSeries(test.values)
+			if s.DType() != test.expected {
+				t.Errorf("expected dtype %s, got %s", test.expected, s.DType())
+			}
+			if s.Len() != len(test.values) {
+				t.Errorf("expected length %d, got %d", len(test.values), s.Len())
+			}
+		})
+	}
+
+	t.Run("uint64 overflow does not wrap", func(t *testing.T) {
+		s := dataframe.NewSeries([]any{uint64(math.MaxUint64)})
+		if got := s.At(0); got != uint64(math.MaxUint64) {
+			t.Errorf("expected %d, got %v (%T)", uint64(math.MaxUint64), got, got)
+		}
+		if _, err := dataframe.NewSeriesAs(dataframe.IntType, []any{uint64(math.MaxUint64)}); err == nil {
+			t.Error("expected an error converting an overflowing uint64 to int64")
+		}
+	})
+
+	t.Run("typed values are not boxed", func(t *testing.T) {
+		s := dataframe.NewSeries([]any{1, 2, 3})
+		ints, ok := s.(*dataframe.IntCol)
+		if !ok {
+			t.Fatalf("expected *IntCol, got %T", s)
+		}
+		if got := ints.Values(); len(got) != 3 || got[0] != 1 || got[2] != 3 {
+			t.Errorf("unexpected values: %v", got)
+		}
+	})
+}
+
+// TestNewSeriesAs tests strict conversion of boxed values into a requested DType.
+func TestNewSeriesAs(t *testing.T) {
+	tests := []struct {
+		name        string
+		dtype       dataframe.DType
+		values      []any
+		expectError bool
+	}{
+		{name: "float column", dtype: dataframe.FloatType, values: []any{1.0, 2.5}},
+		{name: "int column from int", dtype: dataframe.IntType, values: []any{1, 2}},
+		{name: "object column", dtype: dataframe.ObjectType, values: []any{1, "a"}},
+		{name: "type mismatch", dtype: dataframe.FloatType, values: []any{1.0, "a"}, expectError: true},
+		{name: "unknown dtype", dtype: "complex", values: []any{1}, expectError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := dataframe.NewSeriesAs(test.dtype, test.values)
+			if (err != nil) != test.expectError {
+				t.Fatalf("expected error: %v, got: %v", test.expectError, err)
+			}
+			if err == nil && s.DType() != test.dtype {
+				t.Errorf("expected dtype %s, got %s", test.dtype, s.DType())
+			}
+		})
+	}
+}
+
+// TestSeriesTakeAndCopy tests gathering rows with Take and the independence of Copy.
+func TestSeriesTakeAndCopy(t *testing.T) {
+	col := dataframe.NewFloatCol([]float64{1.5, 2.5, 3.5})
+
+	taken := col.Take([]int{2, 0})
+	if taken.DType() != dataframe.FloatType {
+		t.Errorf("expected Take to keep dtype float64, got %s", taken.DType())
+	}
+	if taken.At(0) != 3.5 || taken.At(1) != 1.5 {
+		t.Errorf("unexpected values after Take: %v, %v", taken.At(0), taken.At(1))
+	}
+
+	withMissing := col.Take([]int{0, -1})
+	if !withMissing.IsNull(1) || withMissing.At(1) != nil {
+		t.Errorf("expected null for negative position, got %v", withMissing.At(1))
+	}
+
+	copied := col.Copy().(*dataframe.FloatCol)
+	copied.Values()[0] = 100
+	if col.Value(0) != 1.5 {
+		t.Errorf("expected Copy to be independent of the original, got %v", col.Value(0))
+	}
+}
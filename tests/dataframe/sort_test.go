@@ -0,0 +1,247 @@
+package dataframe_test
+
+import (
+	"gpandas/dataframe"
+	"math"
+	"math/rand"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// newSortFrame returns a frame with ties, nulls and a NaN in its sort columns.
+func newSortFrame() *dataframe.DataFrame {
+	return &dataframe.DataFrame{
+		Columns: []string{"city", "temp", "day"},
+		Data: toSeries([][]any{
+			{"Rome", "Berlin", nil, "Rome", "Berlin", "Paris"},
+			{21.5, 12.0, 15.5, math.NaN(), 12.0, nil},
+			{1, 2, 3, 4, 5, 6},
+		}),
+	}
+}
+
+// TestSortValues tests multi-key sorting, ArgSort and SortIndex.
+//
+// The test suite covers:
+//   - Ascending and descending keys, and one direction for several keys
+//   - Nulls and NaN placed first or last in either direction
+//   - Stability for rows that tie on every key
+//   - Index labels following their rows, and sorting by the index
+//   - A parallel sort of a large frame against a sequential stable sort
+//   - Invalid arguments
+func TestSortValues(t *testing.T) {
+	tests := []struct {
+		name       string
+		by         []string
+		ascending  []bool
+		nullsFirst bool
+		days       []any
+	}{
+		{name: "one key", by: []string{"temp"}, days: []any{int64(2), int64(5), int64(3), int64(1), int64(4), int64(6)}},
+		{name: "descending", by: []string{"temp"}, ascending: []bool{false}, days: []any{int64(1), int64(3), int64(2), int64(5), int64(4), int64(6)}},
+		{name: "nulls first", by: []string{"temp"}, nullsFirst: true, days: []any{int64(4), int64(6), int64(2), int64(5), int64(3), int64(1)}},
+		{name: "strings", by: []string{"city"}, days: []any{int64(2), int64(5), int64(6), int64(1), int64(4), int64(3)}},
+		{
+			name:      "mixed directions",
+			by:        []string{"city", "day"},
+			ascending: []bool{true, false},
+			days:      []any{int64(5), int64(2), int64(6), int64(4), int64(1), int64(3)},
+		},
+		{
+			name:       "descending with nulls first",
+			by:         []string{"city", "temp"},
+			ascending:  []bool{false},
+			nullsFirst: true,
+			days:       []any{int64(3), int64(4), int64(1), int64(6), int64(2), int64(5)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted, err := newSortFrame().SortValues(tt.by, tt.ascending, tt.nullsFirst)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := seriesValues(sorted.Data[2]); !valuesClose(got, tt.days) {
+				t.Errorf("expected days %v, got %v", tt.days, got)
+			}
+			// the index labels follow their rows
+			labels := indexLabels(sorted)
+			for i, day := range tt.days {
+				if labels[i] != day.(int64)-1 {
+					t.Errorf("row %d: expected label %d, got %v", i, day.(int64)-1, labels[i])
+				}
+			}
+		})
+	}
+
+	t.Run("argsort", func(t *testing.T) {
+		order, err := newSortFrame().ArgSort([]string{"temp", "city"}, nil, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := []int{1, 4, 2, 0, 5, 3}; !slices.Equal(order, want) {
+			t.Errorf("expected %v, got %v", want, order)
+		}
+	})
+
+	t.Run("sort index", func(t *testing.T) {
+		df, err := newSortFrame().SetIndex("city")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		sorted, err := df.SortIndex(false, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []any{"Rome", "Rome", "Paris", "Berlin", "Berlin", nil}
+		if got := indexLabels(sorted); !valuesClose(got, want) {
+			t.Errorf("expected labels %v, got %v", want, got)
+		}
+		if got, want := seriesValues(sorted.Data[1]), []any{int64(1), int64(4), int64(6), int64(2), int64(5), int64(3)}; !valuesClose(got, want) {
+			t.Errorf("expected days %v, got %v", want, got)
+		}
+	})
+
+	t.Run("parallel", func(t *testing.T) {
+		// run the parallel merge sort even on a single CPU
+		defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+		const rows = 200_000
+		rng := rand.New(rand.NewSource(22))
+		groups, values := make([]any, rows), make([]any, rows)
+		for i := range groups {
+			groups[i] = rng.Int63n(50)
+			if rng.Intn(20) > 0 {
+				values[i] = float64(rng.Intn(1000))
+			}
+		}
+		df := &dataframe.DataFrame{
+			Columns: []string{"group", "value"},
+			Data:    toSeries([][]any{groups, values}),
+		}
+		order, err := df.ArgSort([]string{"group", "value"}, []bool{true, false}, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := make([]int, rows)
+		for i := range want {
+			want[i] = i
+		}
+		sort.SliceStable(want, func(a, b int) bool {
+			ra, rb := want[a], want[b]
+			if groups[ra] != groups[rb] {
+				return groups[ra].(int64) < groups[rb].(int64)
+			}
+			if values[ra] == nil || values[rb] == nil {
+				return values[ra] == nil && values[rb] != nil
+			}
+			return values[ra].(float64) > values[rb].(float64)
+		})
+		if !slices.Equal(order, want) {
+			t.Errorf("parallel sort differs from a sequential stable sort")
+		}
+	})
+
+	errorTests := []struct {
+		name      string
+		by        []string
+		ascending []bool
+		want      string
+	}{
+		{name: "no columns", want: "at least one sort column"},
+		{name: "missing column", by: []string{"rain"}, want: "rain"},
+		{name: "directions", by: []string{"city", "temp"}, ascending: []bool{true, false, true}, want: "got 3 sort directions for 2 columns"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newSortFrame().SortValues(tt.by, tt.ascending, false)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestNLargest tests NLargest and NSmallest.
+//
+// The test suite covers:
+//   - Selecting fewer, exactly as many and more rows than the frame holds
+//   - Rows with nulls or NaN left out, and ties kept in row order
+//   - Several columns breaking ties
+//   - A large frame against a full sort
+func TestNLargest(t *testing.T) {
+	tests := []struct {
+		name string
+		pick func(*dataframe.DataFrame) (*dataframe.DataFrame, error)
+		days []any
+	}{
+		{
+			name: "largest",
+			pick: func(df *dataframe.DataFrame) (*dataframe.DataFrame, error) { return df.NLargest(2, "temp") },
+			days: []any{int64(1), int64(3)},
+		},
+		{
+			name: "smallest with tie",
+			pick: func(df *dataframe.DataFrame) (*dataframe.DataFrame, error) { return df.NSmallest(2, "temp") },
+			days: []any{int64(2), int64(5)},
+		},
+		{
+			name: "more than available",
+			pick: func(df *dataframe.DataFrame) (*dataframe.DataFrame, error) { return df.NLargest(10, "temp") },
+			days: []any{int64(1), int64(3), int64(2), int64(5)},
+		},
+		{
+			name: "several columns",
+			pick: func(df *dataframe.DataFrame) (*dataframe.DataFrame, error) { return df.NLargest(3, "temp", "day") },
+			days: []any{int64(1), int64(3), int64(5)},
+		},
+		{
+			name: "none",
+			pick: func(df *dataframe.DataFrame) (*dataframe.DataFrame, error) { return df.NSmallest(0, "temp") },
+			days: []any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.pick(newSortFrame())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := seriesValues(result.Data[2]); !valuesClose(got, tt.days) {
+				t.Errorf("expected days %v, got %v", tt.days, got)
+			}
+		})
+	}
+
+	t.Run("large", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(7))
+		values := make([]any, 50_000)
+		for i := range values {
+			values[i] = rng.Int63n(5_000)
+		}
+		df := &dataframe.DataFrame{Columns: []string{"v"}, Data: toSeries([][]any{values})}
+		top, err := df.NLargest(25, "v")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		sorted, err := df.SortValues([]string{"v"}, []bool{false}, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got, want := indexLabels(top), indexLabels(sorted)[:25]; !valuesClose(got, want) {
+			t.Errorf("expected rows %v, got %v", want, got)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := newSortFrame().NLargest(-1, "temp"); err == nil {
+			t.Error("expected an error for a negative n")
+		}
+		if _, err := newSortFrame().NSmallest(1); err == nil {
+			t.Error("expected an error without columns")
+		}
+	})
+}
@@ -0,0 +1,183 @@
+package dataframe_test
+
+import (
+	"gpandas/dataframe"
+	"math"
+	"strings"
+	"testing"
+)
+
+// newStatsFrame returns a frame with a string, an int and a float column, each with a
+// null.
+func newStatsFrame() *dataframe.DataFrame {
+	return &dataframe.DataFrame{
+		Columns: []string{"city", "age", "score"},
+		Data: toSeries([][]any{
+			{"Paris", "Rome", "Paris", nil, "Berlin", "Paris"},
+			{30, 45, nil, 52, 28, 41},
+			{0.5, 1.5, 2.0, nil, 4.0, 10.0},
+		}),
+	}
+}
+
+// TestReductions tests the column reductions of DataFrame.
+//
+// The test suite covers:
+//   - Every reduction on int and float columns with nulls
+//   - Numeric reductions leaving out the string column, Min and Max keeping it
+//   - The stat index naming the reduction
+//   - Invalid quantiles
+func TestReductions(t *testing.T) {
+	tests := []struct {
+		name    string
+		reduce  func(*dataframe.DataFrame) (*dataframe.DataFrame, error)
+		columns []string
+		values  [][]any
+	}{
+		{name: "sum", reduce: (*dataframe.DataFrame).Sum, columns: []string{"age", "score"}, values: [][]any{{int64(196)}, {18.0}}},
+		{name: "mean", reduce: (*dataframe.DataFrame).Mean, columns: []string{"age", "score"}, values: [][]any{{39.2}, {3.6}}},
+		{name: "median", reduce: (*dataframe.DataFrame).Median, columns: []string{"age", "score"}, values: [][]any{{41.0}, {2.0}}},
+		{name: "std", reduce: (*dataframe.DataFrame).Std, columns: []string{"age", "score"}, values: [][]any{{10.134100848126586}, {3.7980258029665888}}},
+		{name: "var", reduce: (*dataframe.DataFrame).Var, columns: []string{"age", "score"}, values: [][]any{{102.7}, {14.425}}},
+		{name: "min", reduce: (*dataframe.DataFrame).Min, columns: []string{"city", "age", "score"}, values: [][]any{{"Berlin"}, {int64(28)}, {0.5}}},
+		{name: "max", reduce: (*dataframe.DataFrame).Max, columns: []string{"city", "age", "score"}, values: [][]any{{"Rome"}, {int64(52)}, {10.0}}},
+		{name: "skew", reduce: (*dataframe.DataFrame).Skew, columns: []string{"age", "score"}, values: [][]any{{0.04583135672344501}, {1.666011937275637}}},
+		{name: "kurt", reduce: (*dataframe.DataFrame).Kurt, columns: []string{"age", "score"}, values: [][]any{{-1.9694442837923303}, {2.789693898699122}}},
+		{
+			name:    "90%",
+			reduce:  func(df *dataframe.DataFrame) (*dataframe.DataFrame, error) { return df.Quantile(0.9) },
+			columns: []string{"age", "score"},
+			values:  [][]any{{49.2}, {7.6}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.reduce(newStatsFrame())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			checkFrame(t, result, tt.columns, tt.values)
+			if labels := indexLabels(result); len(labels) != 1 || labels[0] != tt.name {
+				t.Errorf("expected the row to be labelled %s, got %v", tt.name, labels)
+			}
+			if name := result.RowIndex().Name(); name != dataframe.StatIndexName {
+				t.Errorf("expected the index to be named %s, got %q", dataframe.StatIndexName, name)
+			}
+		})
+	}
+
+	t.Run("too few values", func(t *testing.T) {
+		df := &dataframe.DataFrame{Columns: []string{"x"}, Data: toSeries([][]any{{1.0, 2.0, nil}})}
+		for _, reduce := range []func(*dataframe.DataFrame) (*dataframe.DataFrame, error){
+			(*dataframe.DataFrame).Skew, (*dataframe.DataFrame).Kurt,
+		} {
+			result, err := reduce(df)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !result.Data[0].IsNull(0) {
+				t.Errorf("expected null, got %v", result.Data[0].At(0))
+			}
+		}
+	})
+
+	t.Run("invalid quantile", func(t *testing.T) {
+		_, err := newStatsFrame().Quantile(1.5)
+		if err == nil || !strings.Contains(err.Error(), "between 0 and 1") {
+			t.Errorf("expected an error for an invalid quantile, got %v", err)
+		}
+	})
+}
+
+// TestDescribe tests Describe and ValueCounts.
+//
+// The test suite covers:
+//   - Numeric summaries with the stat names rendered by String
+//   - Count, unique, top and freq for a frame without numeric columns
+//   - Value counts and proportions sorted by count, ties in order of appearance
+//   - Proportions of a column holding NaN, which is not counted
+//   - Columns holding values that cannot be hashed rejected instead of panicking
+func TestDescribe(t *testing.T) {
+	t.Run("numeric", func(t *testing.T) {
+		summary, err := newStatsFrame().Describe()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkFrame(t, summary, []string{"age", "score"}, [][]any{
+			{5.0, 39.2, 10.134100848126586, 28.0, 30.0, 41.0, 45.0, 52.0},
+			{5.0, 3.6, 3.7980258029665888, 0.5, 1.5, 2.0, 4.0, 10.0},
+		})
+		want := []any{"count", "mean", "std", "min", "25%", "50%", "75%", "max"}
+		if labels := indexLabels(summary); !valuesClose(labels, want) {
+			t.Errorf("expected labels %v, got %v", want, labels)
+		}
+		rendered := summary.String()
+		for _, label := range append([]any{"stat"}, want...) {
+			if !strings.Contains(rendered, label.(string)) {
+				t.Errorf("expected String to show %s, got\n%s", label, rendered)
+			}
+		}
+	})
+
+	t.Run("strings", func(t *testing.T) {
+		summary, err := newStatsFrame().Select("city")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		summary, err = summary.Describe()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkFrame(t, summary, []string{"city"}, [][]any{{int64(5), int64(3), "Paris", int64(3)}})
+	})
+
+	t.Run("value counts", func(t *testing.T) {
+		counts, err := newStatsFrame().ValueCounts("city", false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkFrame(t, counts, []string{"count"}, [][]any{{int64(3), int64(1), int64(1)}})
+		if labels, want := indexLabels(counts), []any{"Paris", "Rome", "Berlin"}; !valuesClose(labels, want) {
+			t.Errorf("expected labels %v, got %v", want, labels)
+		}
+		if name := counts.RowIndex().Name(); name != "city" {
+			t.Errorf("expected the index to be named city, got %q", name)
+		}
+
+		shares, err := newStatsFrame().ValueCounts("city", true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkFrame(t, shares, []string{"proportion"}, [][]any{{0.6, 0.2, 0.2}})
+
+		nan := &dataframe.DataFrame{
+			Columns: []string{"x"},
+			Data:    []dataframe.Series{dataframe.NewFloatCol([]float64{1, math.NaN(), 1, 2})},
+		}
+		shares, err = nan.ValueCounts("x", true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkFrame(t, shares, []string{"proportion"}, [][]any{{2.0 / 3, 1.0 / 3}})
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := (&dataframe.DataFrame{}).Describe(); err == nil {
+			t.Error("expected an error for a frame without columns")
+		}
+		if _, err := newStatsFrame().ValueCounts("country", false); err == nil {
+			t.Error("expected an error for a missing column")
+		}
+
+		tags := &dataframe.DataFrame{
+			Columns: []string{"tags"},
+			Data:    []dataframe.Series{dataframe.NewObjectCol([]any{[]int{1}, "a"})},
+		}
+		if _, err := tags.ValueCounts("tags", false); err == nil || !strings.Contains(err.Error(), "unhashable type []int") {
+			t.Errorf("expected an unhashable value error, got %v", err)
+		}
+		if _, err := tags.Describe(); err == nil || !strings.Contains(err.Error(), "unhashable type []int") {
+			t.Errorf("expected an unhashable value error, got %v", err)
+		}
+	})
+}
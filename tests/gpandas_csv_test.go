@@ -0,0 +1,1026 @@
+package gpandas_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"gpandas"
+	"gpandas/dataframe"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// writeCSV writes content to a CSV file in a temporary directory and returns its path.
+func writeCSV(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.csv")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	return path
+}
+
+func TestRead_csvInference(t *testing.T) {
+	tests := []struct {
+		name       string
+		csvContent string
+		opts       gpandas.ReadCSVOptions
+		expected   []dataframe.DType
+	}{
+		{
+			name:       "basic types",
+			csvContent: "i,f,b,d,s\n1,1.5,true,2024-01-02,x\n2,2,FALSE,2024-01-03 10:00:00,y\n",
+			expected:   []dataframe.DType{dataframe.IntType, dataframe.FloatType, dataframe.BoolType, dataframe.DateTimeType, dataframe.StringType},
+		},
+		{
+			name:       "nulls do not affect inference",
+			csvContent: "i,f\n1,\nNA,2.5\n3,NaN\n",
+			expected:   []dataframe.DType{dataframe.IntType, dataframe.FloatType},
+		},
+		{
+			name:       "all null column",
+			csvContent: "a,b\n1,\n2,\n",
+			expected:   []dataframe.DType{dataframe.IntType, dataframe.FloatType},
+		},
+		{
+			name:       "int overflow falls back to float",
+			csvContent: "a\n1\n99999999999999999999\n",
+			expected:   []dataframe.DType{dataframe.FloatType},
+		},
+		{
+			name:       "mixed value outside sample falls back to float",
+			csvContent: "a\n1\n2\n3.5\n",
+			opts:       gpandas.ReadCSVOptions{InferSampleSize: 2},
+			expected:   []dataframe.DType{dataframe.FloatType},
+		},
+		{
+			name:       "text outside sample falls back to string",
+			csvContent: "a\n1\n2\nthree\n",
+			opts:       gpandas.ReadCSVOptions{InferSampleSize: 2},
+			expected:   []dataframe.DType{dataframe.StringType},
+		},
+		{
+			name:       "explicit dtype overrides inference",
+			csvContent: "zip,n\n01234,1\n98765,2\n",
+			opts:       gpandas.ReadCSVOptions{DType: map[string]dataframe.DType{"zip": dataframe.StringType, "n": dataframe.FloatType}},
+			expected:   []dataframe.DType{dataframe.StringType, dataframe.FloatType},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pd := gpandas.GoPandas{}
+			df, err := pd.Read_csv(writeCSV(t, tt.csvContent), tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for i, col := range df.Data {
+				if col.DType() != tt.expected[i] {
+					t.Errorf("column %s: expected %s, got %s", df.Columns[i], tt.expected[i], col.DType())
+				}
+			}
+		})
+	}
+
+	t.Run("parsed values", func(t *testing.T) {
+		pd := gpandas.GoPandas{}
+		df, err := pd.Read_csv(writeCSV(t, "zip,d\n01234,2024-01-02\n98765,\n"), gpandas.ReadCSVOptions{
+			DType: map[string]dataframe.DType{"zip": dataframe.StringType},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if df.Data[0].At(0) != "01234" {
+			t.Errorf("expected zip to keep its leading zero, got %v", df.Data[0].At(0))
+		}
+		expected := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+		if df.Data[1].At(0) != expected || !df.Data[1].IsNull(1) {
+			t.Errorf("unexpected datetimes: %v, %v", df.Data[1].At(0), df.Data[1].At(1))
+		}
+	})
+}
+
+func TestRead_csvExplicitDTypeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts gpandas.ReadCSVOptions
+	}{
+		{
+			name: "value does not parse",
+			opts: gpandas.ReadCSVOptions{DType: map[string]dataframe.DType{"name": dataframe.IntType}},
+		},
+		{
+			name: "unknown column",
+			opts: gpandas.ReadCSVOptions{DType: map[string]dataframe.DType{"missing": dataframe.IntType}},
+		},
+		{
+			name: "unsupported dtype",
+			opts: gpandas.ReadCSVOptions{DType: map[string]dataframe.DType{"age": "complex"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pd := gpandas.GoPandas{}
+			_, err := pd.Read_csv(writeCSV(t, "name,age\nJohn,30\n"), tt.opts)
+			if err == nil {
+				t.Error("expected error but got none")
+			}
+		})
+	}
+}
+
+// sortedColumn returns the values of a column formatted as strings and sorted, so
+// checks do not depend on row order.
+func sortedColumn(s dataframe.Series) []string {
+	values := make([]string, s.Len())
+	for i := range values {
+		values[i] = fmt.Sprintf("%v", s.At(i))
+	}
+	sort.Strings(values)
+	return values
+}
+
+func TestRead_csvOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		csvContent  string
+		opts        gpandas.ReadCSVOptions
+		columns     []string
+		firstColumn []string
+		expectError bool
+	}{
+		{
+			name:        "pipe separator",
+			csvContent:  "a|b\n1|x\n2|y\n",
+			opts:        gpandas.ReadCSVOptions{Sep: '|'},
+			columns:     []string{"a", "b"},
+			firstColumn: []string{"1", "2"},
+		},
+		{
+			name:        "tab separator",
+			csvContent:  "a\tb\n1\tx,y\n",
+			opts:        gpandas.ReadCSVOptions{Sep: '\t'},
+			columns:     []string{"a", "b"},
+			firstColumn: []string{"1"},
+		},
+		{
+			name:        "skip preamble lines",
+			csvContent:  "exported by tool\n\"unbalanced quote\nid,v\n1,2\n",
+			opts:        gpandas.ReadCSVOptions{SkipRows: 2},
+			columns:     []string{"id", "v"},
+			firstColumn: []string{"1"},
+		},
+		{
+			name:        "header index",
+			csvContent:  "report,2024\nid,v\n1,2\n3,4\n",
+			opts:        gpandas.ReadCSVOptions{Header: 1},
+			columns:     []string{"id", "v"},
+			firstColumn: []string{"1", "3"},
+		},
+		{
+			name:        "no header generates names",
+			csvContent:  "1,x\n2,y\n",
+			opts:        gpandas.ReadCSVOptions{Header: gpandas.NoHeader},
+			columns:     []string{"0", "1"},
+			firstColumn: []string{"1", "2"},
+		},
+		{
+			name:        "no header with names",
+			csvContent:  "1,x\n2,y\n",
+			opts:        gpandas.ReadCSVOptions{Header: gpandas.NoHeader, Names: []string{"id", "code"}},
+			columns:     []string{"id", "code"},
+			firstColumn: []string{"1", "2"},
+		},
+		{
+			name:        "usecols keeps file order",
+			csvContent:  "a,b,c\n1,2,3\n4,5,6\n",
+			opts:        gpandas.ReadCSVOptions{UseCols: []string{"c", "a"}},
+			columns:     []string{"a", "c"},
+			firstColumn: []string{"1", "4"},
+		},
+		{
+			name:        "nrows",
+			csvContent:  "a\n1\n2\n3\n4\n",
+			opts:        gpandas.ReadCSVOptions{NRows: 2},
+			columns:     []string{"a"},
+			firstColumn: []string{"1", "2"},
+		},
+		{
+			name:        "comment lines",
+			csvContent:  "a,b\n# generated\n1,2\n#3,4\n5,6\n",
+			opts:        gpandas.ReadCSVOptions{Comment: '#'},
+			columns:     []string{"a", "b"},
+			firstColumn: []string{"1", "5"},
+		},
+		{
+			name:        "custom quote",
+			csvContent:  "a,b\n'x,y',1\n'it''s',2\n",
+			opts:        gpandas.ReadCSVOptions{Quote: '\''},
+			columns:     []string{"a", "b"},
+			firstColumn: []string{"it's", "x,y"},
+		},
+		{
+			name:        "escape character",
+			csvContent:  "a,b\n\"say \\\"hi\\\"\",1\nx\\,y,2\n",
+			opts:        gpandas.ReadCSVOptions{Escape: '\\'},
+			columns:     []string{"a", "b"},
+			firstColumn: []string{"say \"hi\"", "x,y"},
+		},
+		{
+			name:        "quoted newline with custom quote",
+			csvContent:  "a,b\r\n'line1\nline2',1\r\n",
+			opts:        gpandas.ReadCSVOptions{Quote: '\''},
+			columns:     []string{"a", "b"},
+			firstColumn: []string{"line1\nline2"},
+		},
+		{
+			name:        "unknown usecols column",
+			csvContent:  "a,b\n1,2\n",
+			opts:        gpandas.ReadCSVOptions{UseCols: []string{"z"}},
+			expectError: true,
+		},
+		{
+			name:        "names length mismatch",
+			csvContent:  "a,b\n1,2\n",
+			opts:        gpandas.ReadCSVOptions{Names: []string{"x"}},
+			expectError: true,
+		},
+		{
+			name:        "negative nrows",
+			csvContent:  "a\n1\n",
+			opts:        gpandas.ReadCSVOptions{NRows: -1},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pd := gpandas.GoPandas{}
+			df, err := pd.Read_csv(writeCSV(t, tt.csvContent), tt.opts)
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(df.Columns, tt.columns) {
+				t.Errorf("expected columns %v, got %v", tt.columns, df.Columns)
+			}
+			if got := sortedColumn(df.Data[0]); !reflect.DeepEqual(got, tt.firstColumn) {
+				t.Errorf("expected first column %q, got %q", tt.firstColumn, got)
+			}
+		})
+	}
+}
+
+// TestRead_csvPreservesOrder reads a generated file spanning many parsing chunks several
+// times and checks that every row comes back in file order.
+func TestRead_csvPreservesOrder(t *testing.T) {
+	const rowCount = 50000
+
+	var sb strings.Builder
+	sb.WriteString("id,label\n")
+	for i := 0; i < rowCount; i++ {
+		fmt.Fprintf(&sb, "%d,row-%d\n", i, i)
+	}
+	path := writeCSV(t, sb.String())
+
+	pd := gpandas.GoPandas{}
+	for attempt := 0; attempt < 5; attempt++ {
+		df, err := pd.Read_csv(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids, ok := df.Data[0].(*gpandas.IntCol)
+		if !ok {
+			t.Fatalf("expected IntCol ids, got %T", df.Data[0])
+		}
+		if ids.Len() != rowCount {
+			t.Fatalf("expected %d rows, got %d", rowCount, ids.Len())
+		}
+		for i, id := range ids.Values() {
+			if id != int64(i) || df.Data[1].At(i) != fmt.Sprintf("row-%d", i) {
+				t.Fatalf("attempt %d: row %d out of order: id %d, label %v", attempt, i, id, df.Data[1].At(i))
+			}
+		}
+	}
+}
+
+// TestRead_csvRanges reads generated files that are parsed in many byte ranges, with
+// quoted newlines, comments and blank lines around the range boundaries, and checks every
+// record against the value it was written with.
+//
+// The test suite covers:
+//   - Quoted fields spanning lines, doubled quotes, comments and blank lines
+//   - A custom quote and escape character, and lazy quotes
+//   - Bad line numbers deep in the file, NRows and chunked reads spanning ranges
+func TestRead_csvRanges(t *testing.T) {
+	const rowCount = 60000
+
+	// generate writes the header and rowCount records whose second field is written by
+	// field, with comment and blank lines in between, and a bad line before row badRow.
+	generate := func(field func(i int) string, badRow int) string {
+		var sb strings.Builder
+		sb.WriteString("id,text,tail\n")
+		for i := 0; i < rowCount; i++ {
+			if i%97 == 0 {
+				sb.WriteString("# comment, with \"a quote\n")
+			}
+			if i%89 == 0 {
+				sb.WriteString("\n")
+			}
+			if i == badRow {
+				sb.WriteString("bad,line\n")
+			}
+			fmt.Fprintf(&sb, "%d,%s,t%d\n", i, field(i), i)
+		}
+		return sb.String()
+	}
+	want := func(i int) string { return fmt.Sprintf("row %d,\n\"next\" line", i) }
+
+	tests := []struct {
+		name  string
+		field func(i int) string
+		opts  gpandas.ReadCSVOptions
+	}{
+		{
+			name:  "double quotes",
+			field: func(i int) string { return fmt.Sprintf("\"row %d,\n\"\"next\"\" line\"", i) },
+			opts:  gpandas.ReadCSVOptions{Comment: '#'},
+		},
+		{
+			name:  "custom quote and escape",
+			field: func(i int) string { return fmt.Sprintf("'row %d,\n\\\"next\\\" line'", i) },
+			opts:  gpandas.ReadCSVOptions{Comment: '#', Quote: '\'', Escape: '\\'},
+		},
+		{
+			name:  "lazy quotes",
+			field: func(i int) string { return fmt.Sprintf("\"row %d,\n\"next\" line\"", i) },
+			opts:  gpandas.ReadCSVOptions{Comment: '#', LazyQuotes: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pd := gpandas.GoPandas{}
+			df, err := pd.ReadCSVFrom(strings.NewReader(generate(tt.field, -1)), tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if df.Data[0].Len() != rowCount {
+				t.Fatalf("expected %d rows, got %d", rowCount, df.Data[0].Len())
+			}
+			for i := 0; i < rowCount; i++ {
+				if df.Data[0].At(i) != int64(i) || df.Data[1].At(i) != want(i) || df.Data[2].At(i) != fmt.Sprintf("t%d", i) {
+					t.Fatalf("row %d: got %v, %q, %v", i, df.Data[0].At(i), df.Data[1].At(i), df.Data[2].At(i))
+				}
+			}
+		})
+	}
+
+	field := tests[0].field
+	content := generate(field, 50000)
+	badLine := strings.Count(content[:strings.Index(content, "bad,line")], "\n") + 1
+
+	t.Run("bad line number", func(t *testing.T) {
+		pd := gpandas.GoPandas{}
+		_, err := pd.ReadCSVFrom(strings.NewReader(content), gpandas.ReadCSVOptions{Comment: '#'})
+		var bad gpandas.BadLine
+		if !errors.As(err, &bad) || bad.Line != badLine || bad.Raw != "bad,line" {
+			t.Fatalf("expected a BadLine on line %d, got %v", badLine, err)
+		}
+
+		// The bad line lies beyond NRows, so it is never reached
+		df, err := pd.ReadCSVFrom(strings.NewReader(content), gpandas.ReadCSVOptions{Comment: '#', NRows: 30000})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if n := df.Data[0].Len(); n != 30000 || df.Data[1].At(n-1) != want(n-1) {
+			t.Errorf("expected 30000 rows ending with row 29999, got %d", n)
+		}
+	})
+
+	t.Run("chunks", func(t *testing.T) {
+		pd := gpandas.GoPandas{}
+		var reported []gpandas.BadLine
+		chunks, err := pd.ReadCSVChunksFrom(strings.NewReader(content), 7000, gpandas.ReadCSVOptions{
+			Comment:         '#',
+			OnBadLines:      gpandas.BadLinesWarn,
+			BadLineCallback: func(b gpandas.BadLine) { reported = append(reported, b) },
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer chunks.Close()
+		row := 0
+		for {
+			df, err := chunks.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for i := 0; i < df.Data[1].Len(); i++ {
+				if df.Data[1].At(i) != want(row) {
+					t.Fatalf("row %d: got %q", row, df.Data[1].At(i))
+				}
+				row++
+			}
+			if row <= 50000 && len(reported) > 0 {
+				t.Errorf("bad line reported before row 50000, at row %d", row)
+			}
+		}
+		if row != rowCount || len(reported) != 1 || reported[0].Line != badLine {
+			t.Errorf("expected %d rows and one bad line on line %d, got %d rows and %v", rowCount, badLine, row, reported)
+		}
+	})
+}
+
+// TestRead_csvBadLines checks each OnBadLines policy and that bad lines are reported with
+// the line number and raw text they had in the file.
+func TestRead_csvBadLines(t *testing.T) {
+	const content = "# exported\na,b\n1,2\n3,4,5\n# note\n6,7\n8\n"
+	opts := gpandas.ReadCSVOptions{SkipRows: 1, Comment: '#'}
+
+	t.Run("error", func(t *testing.T) {
+		pd := gpandas.GoPandas{}
+		_, err := pd.Read_csv(writeCSV(t, content), opts)
+		var bad gpandas.BadLine
+		if !errors.As(err, &bad) {
+			t.Fatalf("expected BadLine error, got %v", err)
+		}
+		if bad.Line != 4 || bad.Raw != "3,4,5" || bad.Expected != 2 || len(bad.Fields) != 3 {
+			t.Errorf("unexpected bad line: %+v", bad)
+		}
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		pd := gpandas.GoPandas{}
+		skipOpts := opts
+		skipOpts.OnBadLines = gpandas.BadLinesSkip
+		df, err := pd.Read_csv(writeCSV(t, content), skipOpts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := sortedColumn(df.Data[0]); !reflect.DeepEqual(got, []string{"1", "6"}) {
+			t.Errorf("expected rows 1 and 6, got %v", got)
+		}
+	})
+
+	t.Run("warn", func(t *testing.T) {
+		pd := gpandas.GoPandas{}
+		var reported []gpandas.BadLine
+		warnOpts := opts
+		warnOpts.OnBadLines = gpandas.BadLinesWarn
+		warnOpts.BadLineCallback = func(b gpandas.BadLine) { reported = append(reported, b) }
+		df, err := pd.Read_csv(writeCSV(t, content), warnOpts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if df.Data[0].Len() != 2 {
+			t.Errorf("expected 2 rows, got %d", df.Data[0].Len())
+		}
+		if len(reported) != 2 {
+			t.Fatalf("expected 2 bad lines, got %d", len(reported))
+		}
+		if reported[0].Line != 4 || reported[1].Line != 7 || reported[1].Raw != "8" {
+			t.Errorf("unexpected bad lines: %+v", reported)
+		}
+	})
+
+	t.Run("skipped rows do not count towards nrows", func(t *testing.T) {
+		pd := gpandas.GoPandas{}
+		df, err := pd.Read_csv(writeCSV(t, "a,b\n1\n2,3\n4,5\n"), gpandas.ReadCSVOptions{OnBadLines: gpandas.BadLinesSkip, NRows: 2})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := sortedColumn(df.Data[0]); !reflect.DeepEqual(got, []string{"2", "4"}) {
+			t.Errorf("expected rows 2 and 4, got %v", got)
+		}
+	})
+
+	t.Run("custom quote reader", func(t *testing.T) {
+		pd := gpandas.GoPandas{}
+		_, err := pd.Read_csv(writeCSV(t, "a,b\n'x,y',1\n'p',2,3\n"), gpandas.ReadCSVOptions{Quote: '\''})
+		var bad gpandas.BadLine
+		if !errors.As(err, &bad) {
+			t.Fatalf("expected BadLine error, got %v", err)
+		}
+		if bad.Line != 3 || bad.Raw != "'p',2,3" {
+			t.Errorf("unexpected bad line: %+v", bad)
+		}
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		pd := gpandas.GoPandas{}
+		if _, err := pd.Read_csv(writeCSV(t, "a\n1\n"), gpandas.ReadCSVOptions{OnBadLines: "ignore"}); err == nil {
+			t.Error("expected error but got none")
+		}
+	})
+}
+
+// TestRead_csvReaderError checks that a parse failure part way through the file is returned
+// instead of a partial DataFrame.
+func TestRead_csvReaderError(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("a,b\n")
+	for i := 0; i < 5000; i++ {
+		fmt.Fprintf(&sb, "%d,x\n", i)
+	}
+	sb.WriteString("1,\"unterminated\n2,y\n")
+
+	pd := gpandas.GoPandas{}
+	df, err := pd.Read_csv(writeCSV(t, sb.String()))
+	if err == nil {
+		t.Fatalf("expected error, got DataFrame with %d rows", df.Data[0].Len())
+	}
+	var parseErr *csv.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected csv.ParseError, got %v", err)
+	}
+}
+
+// peopleCSV is the plain text shared by the compression tests.
+const peopleCSV = "name,age\nalice,30\nbob,25\n"
+
+// peopleCSVBzip2 is peopleCSV compressed with bzip2, which the standard library can only
+// decompress.
+var peopleCSVBzip2 = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0x4d, 0x65, 0x25, 0xa2, 0x00, 0x00,
+	0x0b, 0xd9, 0x00, 0x00, 0x10, 0x00, 0x04, 0x5a, 0x00, 0x3a, 0xa7, 0xa0, 0x00, 0x31, 0x4c, 0x00,
+	0x13, 0x42, 0x26, 0x9a, 0x36, 0xa0, 0x7a, 0x6a, 0x6e, 0x27, 0x50, 0xc2, 0x58, 0x38, 0xa5, 0x72,
+	0x4a, 0xa4, 0xed, 0x27, 0xe2, 0xee, 0x48, 0xa7, 0x0a, 0x12, 0x09, 0xac, 0xa4, 0xb4, 0x40,
+}
+
+// compressCSV returns peopleCSV compressed with the given format.
+func compressCSV(t *testing.T, compression gpandas.Compression) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	switch compression {
+	case gpandas.CompressionGzip:
+		w := gzip.NewWriter(&buf)
+		w.Write([]byte(peopleCSV))
+		w.Close()
+	case gpandas.CompressionZstd:
+		w, err := zstd.NewWriter(&buf)
+		if err != nil {
+			t.Fatalf("failed to create zstd writer: %v", err)
+		}
+		w.Write([]byte(peopleCSV))
+		w.Close()
+	case gpandas.CompressionBzip2:
+		buf.Write(peopleCSVBzip2)
+	default:
+		buf.WriteString(peopleCSV)
+	}
+	return buf.Bytes()
+}
+
+// checkPeople verifies that df holds the rows of peopleCSV.
+func checkPeople(t *testing.T, df *dataframe.DataFrame) {
+	t.Helper()
+	if !reflect.DeepEqual(df.Columns, []string{"name", "age"}) {
+		t.Fatalf("expected columns [name age], got %v", df.Columns)
+	}
+	ages, ok := df.Data[1].(*gpandas.IntCol)
+	if !ok {
+		t.Fatalf("expected IntCol ages, got %T", df.Data[1])
+	}
+	if !reflect.DeepEqual(ages.Values(), []int64{30, 25}) || df.Data[0].At(1) != "bob" {
+		t.Errorf("unexpected rows: %v %v", df.Data[0], ages.Values())
+	}
+}
+
+func TestRead_csvCompression(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		compression gpandas.Compression
+		opts        gpandas.ReadCSVOptions
+	}{
+		{name: "gzip by extension", file: "people.csv.gz", compression: gpandas.CompressionGzip},
+		{name: "bzip2 by extension", file: "people.csv.bz2", compression: gpandas.CompressionBzip2},
+		{name: "zstd by extension", file: "people.csv.zst", compression: gpandas.CompressionZstd},
+		{name: "gzip by magic bytes", file: "people.dat", compression: gpandas.CompressionGzip},
+		{name: "bzip2 by magic bytes", file: "people.dat", compression: gpandas.CompressionBzip2},
+		{name: "zstd by magic bytes", file: "people.dat", compression: gpandas.CompressionZstd},
+		{name: "plain text", file: "people.csv", compression: gpandas.CompressionNone},
+		{
+			name:        "explicit option overrides extension",
+			file:        "people.csv.gz",
+			compression: gpandas.CompressionNone,
+			opts:        gpandas.ReadCSVOptions{Compression: gpandas.CompressionNone},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, compressCSV(t, tt.compression), 0644); err != nil {
+				t.Fatalf("failed to create test file: %v", err)
+			}
+			pd := gpandas.GoPandas{}
+			df, err := pd.Read_csv(path, tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			checkPeople(t, df)
+		})
+	}
+
+	t.Run("corrupt gzip stream", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "people.csv.gz")
+		if err := os.WriteFile(path, []byte(peopleCSV), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+		pd := gpandas.GoPandas{}
+		if _, err := pd.Read_csv(path); err == nil {
+			t.Error("expected error but got none")
+		}
+	})
+}
+
+func TestReadCSVFrom(t *testing.T) {
+	pd := gpandas.GoPandas{}
+	for _, compression := range []gpandas.Compression{
+		gpandas.CompressionNone,
+		gpandas.CompressionGzip,
+		gpandas.CompressionBzip2,
+		gpandas.CompressionZstd,
+	} {
+		t.Run(string(compression), func(t *testing.T) {
+			df, err := pd.ReadCSVFrom(bytes.NewReader(compressCSV(t, compression)))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			checkPeople(t, df)
+		})
+	}
+
+	t.Run("options", func(t *testing.T) {
+		df, err := pd.ReadCSVFrom(strings.NewReader("# preamble\nname|age\nalice|30\nbob|25\n"), gpandas.ReadCSVOptions{Sep: '|', SkipRows: 1})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkPeople(t, df)
+	})
+
+	t.Run("unsupported compression", func(t *testing.T) {
+		if _, err := pd.ReadCSVFrom(strings.NewReader(peopleCSV), gpandas.ReadCSVOptions{Compression: "lz4"}); err == nil {
+			t.Error("expected error but got none")
+		}
+	})
+}
+
+// collectChunks reads every chunk from chunks and returns their row counts and the ids in
+// the first column.
+func collectChunks(t *testing.T, chunks *gpandas.CSVChunkReader) ([]int, []int64, error) {
+	t.Helper()
+	var sizes []int
+	var ids []int64
+	for {
+		df, err := chunks.Next()
+		if err == io.EOF {
+			return sizes, ids, nil
+		}
+		if err != nil {
+			return sizes, ids, err
+		}
+		col, ok := df.Data[0].(*gpandas.IntCol)
+		if !ok {
+			t.Fatalf("expected IntCol ids, got %T", df.Data[0])
+		}
+		sizes = append(sizes, col.Len())
+		ids = append(ids, col.Values()...)
+	}
+}
+
+func TestReadCSVChunks(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("id,score\n")
+	for i := 0; i < 2500; i++ {
+		fmt.Fprintf(&sb, "%d,%d\n", i, i%7)
+	}
+	path := writeCSV(t, sb.String())
+
+	tests := []struct {
+		name      string
+		chunkSize int
+		opts      gpandas.ReadCSVOptions
+		sizes     []int
+	}{
+		{name: "even chunks", chunkSize: 500, sizes: []int{500, 500, 500, 500, 500}},
+		{name: "last chunk partial", chunkSize: 1000, sizes: []int{1000, 1000, 500}},
+		{name: "nrows spans chunks", chunkSize: 1000, opts: gpandas.ReadCSVOptions{NRows: 1200}, sizes: []int{1000, 200}},
+		{name: "single chunk", chunkSize: 10000, sizes: []int{2500}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pd := gpandas.GoPandas{}
+			chunks, err := pd.ReadCSVChunks(path, tt.chunkSize, tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer chunks.Close()
+
+			sizes, ids, err := collectChunks(t, chunks)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(sizes, tt.sizes) {
+				t.Errorf("expected chunk sizes %v, got %v", tt.sizes, sizes)
+			}
+			for i, id := range ids {
+				if id != int64(i) {
+					t.Fatalf("row %d out of order: got id %d", i, id)
+				}
+			}
+			if _, err := chunks.Next(); err != io.EOF {
+				t.Errorf("expected io.EOF after the last chunk, got %v", err)
+			}
+		})
+	}
+}
+
+func TestReadCSVChunksSchema(t *testing.T) {
+	pd := gpandas.GoPandas{}
+
+	t.Run("dtypes fixed by first chunk", func(t *testing.T) {
+		// The second chunk holds only nulls in b, which would infer as float64 on its own
+		chunks, err := pd.ReadCSVChunksFrom(strings.NewReader("a,b\n1,x\n2,y\n3,\n4,\n"), 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer chunks.Close()
+		if chunks.DTypes() != nil {
+			t.Errorf("expected no dtypes before the first chunk, got %v", chunks.DTypes())
+		}
+		var dtypes [][]dataframe.DType
+		chunks.All()(func(df *dataframe.DataFrame, err error) bool {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			dtypes = append(dtypes, []dataframe.DType{df.Data[0].DType(), df.Data[1].DType()})
+			return true
+		})
+		want := [][]dataframe.DType{
+			{dataframe.IntType, dataframe.StringType},
+			{dataframe.IntType, dataframe.StringType},
+		}
+		if !reflect.DeepEqual(dtypes, want) {
+			t.Errorf("expected dtypes %v, got %v", want, dtypes)
+		}
+		if !reflect.DeepEqual(chunks.Columns(), []string{"a", "b"}) {
+			t.Errorf("expected columns [a b], got %v", chunks.Columns())
+		}
+	})
+
+	t.Run("all-null column resolved by a later chunk", func(t *testing.T) {
+		chunks, err := pd.ReadCSVChunksFrom(strings.NewReader("a,b\n1,\n2,\n3,x\n4,\n5,y\n"), 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer chunks.Close()
+		var dtypes []dataframe.DType
+		var nulls []int
+		chunks.All()(func(df *dataframe.DataFrame, err error) bool {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			dtypes = append(dtypes, df.Data[1].DType())
+			nulls = append(nulls, df.Data[1].NullCount())
+			return true
+		})
+		wantDTypes := []dataframe.DType{dataframe.FloatType, dataframe.StringType, dataframe.StringType}
+		if !reflect.DeepEqual(dtypes, wantDTypes) {
+			t.Errorf("expected dtypes %v, got %v", wantDTypes, dtypes)
+		}
+		if !reflect.DeepEqual(nulls, []int{2, 1, 0}) {
+			t.Errorf("expected null counts [2 1 0], got %v", nulls)
+		}
+		want := map[string]dataframe.DType{"a": dataframe.IntType, "b": dataframe.StringType}
+		if !reflect.DeepEqual(chunks.DTypes(), want) {
+			t.Errorf("expected dtypes %v, got %v", want, chunks.DTypes())
+		}
+	})
+
+	t.Run("unresolved column left out of dtypes", func(t *testing.T) {
+		chunks, err := pd.ReadCSVChunksFrom(strings.NewReader("a,b\n1,\n2,\n3,x\n"), 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer chunks.Close()
+		if _, err := chunks.Next(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := map[string]dataframe.DType{"a": dataframe.IntType}
+		if !reflect.DeepEqual(chunks.DTypes(), want) {
+			t.Errorf("expected dtypes %v, got %v", want, chunks.DTypes())
+		}
+	})
+
+	t.Run("chunks own their columns", func(t *testing.T) {
+		chunks, err := pd.ReadCSVChunksFrom(strings.NewReader("a,b\n1.5,x\n2.5,y\n3,z\n"), 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer chunks.Close()
+		first, err := chunks.Next()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := first.Rename(map[string]string{"a": "renamed"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		chunks.Columns()[1] = "changed"
+
+		second, err := chunks.Next()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(second.Columns, []string{"a", "b"}) {
+			t.Errorf("expected columns [a b] in the second chunk, got %v", second.Columns)
+		}
+		// 3 alone would infer as int64; the schema of the first chunk keeps it float64
+		if dtype := second.Data[0].DType(); dtype != dataframe.FloatType {
+			t.Errorf("expected a to stay %s, got %s", dataframe.FloatType, dtype)
+		}
+		if !reflect.DeepEqual(chunks.Columns(), []string{"a", "b"}) {
+			t.Errorf("expected the reader columns [a b], got %v", chunks.Columns())
+		}
+	})
+
+	t.Run("later value outside the first chunk's dtype", func(t *testing.T) {
+		chunks, err := pd.ReadCSVChunksFrom(strings.NewReader("a\n1\n2\n3.5\n"), 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer chunks.Close()
+		_, _, err = collectChunks(t, chunks)
+		if err == nil {
+			t.Fatal("expected error but got none")
+		}
+		if msg := err.Error(); !strings.Contains(msg, "chunk 1") || !strings.Contains(msg, "column a") {
+			t.Errorf("expected the error to name chunk 1 and column a, got %q", msg)
+		}
+	})
+
+	t.Run("explicit dtype avoids the conflict", func(t *testing.T) {
+		chunks, err := pd.ReadCSVChunksFrom(strings.NewReader("a\n1\n2\n3.5\n"), 2, gpandas.ReadCSVOptions{
+			DType: map[string]dataframe.DType{"a": dataframe.FloatType},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer chunks.Close()
+		count := 0
+		chunks.All()(func(df *dataframe.DataFrame, err error) bool {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			count += df.Data[0].Len()
+			return true
+		})
+		if count != 3 {
+			t.Errorf("expected 3 rows, got %d", count)
+		}
+	})
+
+	t.Run("invalid chunk size", func(t *testing.T) {
+		if _, err := pd.ReadCSVChunksFrom(strings.NewReader(peopleCSV), 0); err == nil {
+			t.Error("expected error but got none")
+		}
+	})
+
+	t.Run("header only", func(t *testing.T) {
+		chunks, err := pd.ReadCSVChunksFrom(strings.NewReader("a,b\n"), 10)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer chunks.Close()
+		if _, err := chunks.Next(); err == nil || err == io.EOF {
+			t.Errorf("expected no data rows error, got %v", err)
+		}
+	})
+}
+
+// TestWriteCSVRoundTrip writes frames with awkward values through WriteCSV in every quoting
+// mode that can represent them and checks that Read_csv reads back the same values and
+// dtypes.
+func TestWriteCSVRoundTrip(t *testing.T) {
+	stamp := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	strs := dataframe.NewStringCol([]string{`Smith, "Jr"`, "line\nbreak", " lead", "semi;colon", "tab\there", "plain"})
+	strs.SetNull(5)
+	floats := dataframe.NewFloatCol([]float64{1.5, -0.25, 1e21, 3, 0.1, 2})
+	floats.SetNull(1)
+	original := &dataframe.DataFrame{
+		Columns: []string{"text", "float", "int", "bool", "when", `quoted "name", here`},
+		Data: []dataframe.Series{
+			strs,
+			floats,
+			dataframe.NewIntCol([]int64{1, -2, 3, 1 << 40, 5, 6}),
+			dataframe.NewBoolCol([]bool{true, false, true, true, false, false}),
+			dataframe.NewDateTimeCol([]time.Time{stamp, stamp.Add(time.Hour), stamp, stamp, stamp, stamp.AddDate(0, 0, 1)}),
+			dataframe.NewIntCol([]int64{6, 5, 4, 3, 2, 1}),
+		},
+	}
+
+	tests := []struct {
+		name string
+		opts dataframe.ToCSVOptions
+		sep  rune
+	}{
+		{name: "minimal", opts: dataframe.ToCSVOptions{}},
+		{name: "all", opts: dataframe.ToCSVOptions{Quoting: dataframe.QuoteAll}},
+		{name: "non-numeric", opts: dataframe.ToCSVOptions{Quoting: dataframe.QuoteNonNumeric}},
+		{name: "semicolon and CRLF", opts: dataframe.ToCSVOptions{Separator: ";", LineTerminator: "\r\n"}, sep: ';'},
+		{name: "tab and null token", opts: dataframe.ToCSVOptions{Separator: "\t", NullRep: "NA"}, sep: '\t'},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := original.WriteCSV(&buf, tt.opts); err != nil {
+				t.Fatalf("unexpected write error: %v", err)
+			}
+			pd := gpandas.GoPandas{}
+			df, err := pd.ReadCSVFrom(&buf, gpandas.ReadCSVOptions{Sep: tt.sep})
+			if err != nil {
+				t.Fatalf("unexpected read error: %v\n%s", err, buf.String())
+			}
+			if !reflect.DeepEqual(df.Columns, original.Columns) {
+				t.Fatalf("expected columns %q, got %q", original.Columns, df.Columns)
+			}
+			for c, col := range original.Data {
+				got := df.Data[c]
+				if got.DType() != col.DType() {
+					t.Errorf("column %s: expected dtype %s, got %s", original.Columns[c], col.DType(), got.DType())
+					continue
+				}
+				for r := 0; r < col.Len(); r++ {
+					want, have := col.At(r), got.At(r)
+					if wt, ok := want.(time.Time); ok {
+						if ht, ok := have.(time.Time); !ok || !wt.Equal(ht) {
+							t.Errorf("column %s row %d: expected %v, got %v", original.Columns[c], r, want, have)
+						}
+						continue
+					}
+					if want != have {
+						t.Errorf("column %s row %d: expected %#v, got %#v", original.Columns[c], r, want, have)
+					}
+				}
+			}
+		})
+	}
+	// A lone empty field would be written as a blank line, which readers skip
+	t.Run("single column with empty fields", func(t *testing.T) {
+		floats := dataframe.NewFloatCol([]float64{1.5, 0, 2.5})
+		floats.SetNull(1)
+		frames := []*dataframe.DataFrame{
+			{Columns: []string{"text"}, Data: []dataframe.Series{dataframe.NewStringCol([]string{"a", "", "b", "c"})}},
+			{Columns: []string{"value"}, Data: []dataframe.Series{floats}},
+		}
+		for _, frame := range frames {
+			for _, quoting := range []dataframe.QuoteMode{dataframe.QuoteMinimal, dataframe.QuoteNonNumeric} {
+				var buf bytes.Buffer
+				if err := frame.WriteCSV(&buf, dataframe.ToCSVOptions{Quoting: quoting}); err != nil {
+					t.Fatalf("unexpected write error: %v", err)
+				}
+				written := buf.String()
+				pd := gpandas.GoPandas{}
+				df, err := pd.ReadCSVFrom(&buf)
+				if err != nil {
+					t.Fatalf("unexpected read error: %v\n%s", err, written)
+				}
+				want := frame.Data[0]
+				if got := df.Data[0]; got.Len() != want.Len() || !got.IsNull(1) || got.At(2) != want.At(2) {
+					t.Errorf("%s, %s: expected %v back, got %v from\n%s", frame.Columns[0], quoting, seriesString(want), seriesString(got), written)
+				}
+			}
+		}
+	})
+}
+
+// seriesString formats the values of a Series for error messages.
+func seriesString(s dataframe.Series) string {
+	values := make([]any, s.Len())
+	for i := range values {
+		values[i] = s.At(i)
+	}
+	return fmt.Sprint(values)
+}
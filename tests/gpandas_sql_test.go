@@ -139,11 +139,11 @@ func TestRead_sql(t *testing.T) {
 				// For non-empty result sets, check data consistency
 				if len(df.Data) > 0 {
 					// Check if all columns have the same length
-					firstColLen := len(df.Data[0])
+					firstColLen := df.Data[0].Len()
 					for i, col := range df.Data {
-						if len(col) != firstColLen {
+						if col.Len() != firstColLen {
 							t.Errorf("column %d has inconsistent length: expected %d, got %d",
-								i, firstColLen, len(col))
+								i, firstColLen, col.Len())
 						}
 					}
 				}
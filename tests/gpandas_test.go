@@ -2,6 +2,7 @@ package gpandas_test
 
 import (
 	"gpandas"
+	"gpandas/dataframe"
 	"os"
 	"path/filepath"
 	"testing"
@@ -102,11 +103,11 @@ Bob,"Data Scientist, ML",Paris`,
 				}
 
 				// Check if all columns have the same length
-				firstColLen := len(df.Data[0])
+				firstColLen := df.Data[0].Len()
 				for i, col := range df.Data {
-					if len(col) != firstColLen {
+					if col.Len() != firstColLen {
 						t.Errorf("column %d has inconsistent length: expected %d, got %d",
-							i, firstColLen, len(col))
+							i, firstColLen, col.Len())
 					}
 				}
 			}
@@ -149,13 +150,138 @@ Bob,35,true,92.8`
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	// Verify all values are StringCol (correct behavior)
+	// Verify every column has its inferred dtype
+	expected := []dataframe.DType{dataframe.StringType, dataframe.IntType, dataframe.BoolType, dataframe.FloatType}
 	for i, col := range df.Data {
-		for j, val := range col {
-			if _, ok := val.(gpandas.StringCol); !ok {
-				t.Errorf("expected StringCol type for value at column %d row %d, got %T",
-					i, j, val)
-			}
+		if col.DType() != expected[i] {
+			t.Errorf("expected %s type for column %s, got %s", expected[i], df.Columns[i], col.DType())
+		}
+	}
+	if ages, ok := df.Data[1].(*gpandas.IntCol); !ok || ages.Value(2) != 35 {
+		t.Errorf("expected IntCol ages ending in 35, got %v", df.Data[1])
+	}
+}
+
+func TestDataFrame(t *testing.T) {
+	pd := gpandas.GoPandas{}
+
+	t.Run("typed columns", func(t *testing.T) {
+		df, err := pd.DataFrame(
+			[]string{"name", "age", "score", "active"},
+			[]gpandas.Column{
+				{"John", "Alice"},
+				{30, 25},
+				{95.5, 87.3},
+				{true, false},
+			},
+			map[string]any{
+				"name":   gpandas.StringCol{},
+				"age":    gpandas.IntCol{},
+				"score":  gpandas.FloatCol{},
+				"active": gpandas.BoolCol{},
+			},
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ages, ok := df.Data[1].(*gpandas.IntCol)
+		if !ok {
+			t.Fatalf("expected IntCol for age, got %T", df.Data[1])
+		}
+		if ages.Value(0) != 30 || ages.Value(1) != 25 {
+			t.Errorf("unexpected ages: %v", ages.Values())
+		}
+		scores, ok := df.Data[2].(*gpandas.FloatCol)
+		if !ok {
+			t.Fatalf("expected FloatCol for score, got %T", df.Data[2])
+		}
+		if scores.Value(1) != 87.3 {
+			t.Errorf("unexpected scores: %v", scores.Values())
+		}
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		_, err := pd.DataFrame(
+			[]string{"score"},
+			[]gpandas.Column{{95.5, "high"}},
+			map[string]any{"score": gpandas.FloatCol{}},
+		)
+		if err == nil {
+			t.Error("expected error but got none")
+		}
+	})
+}
+
+// TestRead_csvCrossPath loads CSV files through Read_csv and verifies that the
+// column-major frames it produces round-trip through Merge and ToCSV.
+func TestRead_csvCrossPath(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	people := "id,name\n1,Alice\n2,Bob\n3,Charlie\n"
+	ages := "id,age,city\n1,25,Paris\n2,30,London\n4,35,Berlin\n"
+
+	peopleFile := filepath.Join(tmpDir, "people.csv")
+	agesFile := filepath.Join(tmpDir, "ages.csv")
+	if err := os.WriteFile(peopleFile, []byte(people), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	if err := os.WriteFile(agesFile, []byte(ages), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	pd := gpandas.GoPandas{}
+	left, err := pd.Read_csv(peopleFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	right, err := pd.Read_csv(agesFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("round trip through ToCSV", func(t *testing.T) {
+		out, err := left.ToCSV("")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if out != people {
+			t.Errorf("expected:\n%s\ngot:\n%s", people, out)
+		}
+	})
+
+	t.Run("round trip through Merge and ToCSV", func(t *testing.T) {
+		merged, err := left.Merge(right, "id", dataframe.InnerMerge)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out, err := merged.ToCSV("")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := "id,name,age,city\n1,Alice,25,Paris\n2,Bob,30,London\n"
+		if out != expected {
+			t.Errorf("expected:\n%s\ngot:\n%s", expected, out)
 		}
+	})
+}
+
+func TestRead_csvNulls(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "nulls.csv")
+	csvContent := "name,city\nJohn,\nAlice,NA\nBob,Paris\n"
+	if err := os.WriteFile(testFile, []byte(csvContent), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	pd := gpandas.GoPandas{}
+	df, err := pd.Read_csv(testFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if df.Data[1].NullCount() != 2 {
+		t.Errorf("expected 2 nulls in city, got %d", df.Data[1].NullCount())
+	}
+	if df.Data[0].NullCount() != 0 {
+		t.Errorf("expected no nulls in name, got %d", df.Data[0].NullCount())
 	}
 }